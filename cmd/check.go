@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"boxed/internal/check"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultCheckTimeout matches the --max-time the curl-based example scripts used.
+const defaultCheckTimeout = 5 * time.Second
+
+// ExecuteCheckHTTP requests every URL concurrently and renders one box with a row
+// per endpoint. The box type is the worst endpoint result.
+func (e *Executor) ExecuteCheckHTTP(ctx context.Context, urls []string, opts check.HTTPOptions, title string, exitOnError, exitOnWarning bool) error {
+	now := time.Now()
+	results := check.CheckHTTP(ctx, urls, opts, now)
+
+	b := check.HTTPBox(results, opts, now)
+	if title != "" {
+		b.Title = title
+	}
+	return e.printBox(b, exitOnError, exitOnWarning)
+}
+
+// ExecuteCheckTLS reports on the certificate chain presented by addr, or on a local
+// PEM file when certFile is set, so certificates can be checked before deployment
+// or without network access.
+func (e *Executor) ExecuteCheckTLS(ctx context.Context, addr, certFile, caFile string, opts check.TLSOptions, title string, exitOnError, exitOnWarning bool) error {
+	if caFile != "" {
+		data, err := os.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("failed to read CA file: %w", err)
+		}
+		opts.Roots, err = check.ReadCertPool(data, caFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	var report check.CertReport
+	var err error
+	if certFile != "" {
+		data, readErr := os.ReadFile(certFile)
+		if readErr != nil {
+			return fmt.Errorf("failed to read certificate file: %w", readErr)
+		}
+		report, err = check.InspectPEM(data, certFile, opts, now)
+	} else {
+		report, err = check.InspectTLS(ctx, addr, opts, now)
+	}
+	if err != nil {
+		return err
+	}
+
+	b := check.TLSBox(report, opts, now)
+	if title != "" {
+		b.Title = title
+	}
+	return e.printBox(b, exitOnError, exitOnWarning)
+}
+
+// newCheckCmd groups the built-in checks. They exit non-zero on failures by default,
+// like the auto type, since their usual job is gating a script or CI step.
+func newCheckCmd(executor *Executor) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Run built-in health checks and show the results in a box",
+	}
+	cmd.AddCommand(newCheckHTTPCmd(executor), newCheckTLSCmd(executor))
+	return cmd
+}
+
+func newCheckHTTPCmd(executor *Executor) *cobra.Command {
+	var opts check.HTTPOptions
+	var title string
+	var exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:   "http <url>...",
+		Short: "Check HTTP endpoints",
+		Long: `Request each URL concurrently and show its status code, latency, certificate expiry
+(for HTTPS) and content check in one row per endpoint. An endpoint fails when it
+can't be reached, times out, returns an unexpected status or lacks the --contains
+text; it warns when slower than --slow or when its certificate expires within
+--warn-days. The box takes the worst result.`,
+		Example: `  boxed check http https://api.example.com/health https://example.com
+  boxed check http --contains '"status":"ok"' --slow 500ms https://api.example.com/health
+  boxed check http --expect-status 200 --expect-status 301 http://example.com`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Timeout <= 0 {
+				return fmt.Errorf("timeout must be positive, got %s", opts.Timeout)
+			}
+			return executor.ExecuteCheckHTTP(cmd.Context(), args, opts, title, exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Box title (default \"HTTP Check\")")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", defaultCheckTimeout, "Timeout for each request")
+	cmd.Flags().IntSliceVar(&opts.ExpectStatus, "expect-status", nil, "Acceptable status code (repeatable; default any 2xx)")
+	cmd.Flags().StringVar(&opts.Contains, "contains", "", "Text the response body must contain")
+	cmd.Flags().DurationVar(&opts.SlowAfter, "slow", 0, "Warn when a response takes longer than this (0 disables)")
+	cmd.Flags().IntVar(&opts.WarnDays, "warn-days", check.DefaultWarnDays, "Warn when a certificate expires within this many days")
+	cmd.Flags().IntVar(&opts.ErrorDays, "error-days", check.DefaultErrorDays, "Fail when a certificate expires within this many days")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", true, "Exit with code 1 when a check fails")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", true, "Exit with code 2 when a check warns")
+
+	return cmd
+}
+
+func newCheckTLSCmd(executor *Executor) *cobra.Command {
+	var opts check.TLSOptions
+	var certFile, caFile, title string
+	var exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:   "tls [host:port]",
+		Short: "Inspect a TLS certificate",
+		Long: `Show the subject, SANs, issuer, chain validity and expiry of the certificate a
+server presents (port defaults to 443), or of a local PEM file with --cert. The
+chain is verified against the system roots, or --ca for private CAs; the box
+warns within --warn-days of expiry and fails within --error-days.`,
+		Example: `  boxed check tls example.com
+  boxed check tls localhost:8443 --ca dev-ca.pem
+  boxed check tls --cert server.pem --server-name api.example.com --warn-days 45`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (certFile == "") {
+				return fmt.Errorf("give either a host:port to connect to or --cert with a PEM file")
+			}
+			addr := ""
+			if len(args) == 1 {
+				addr = args[0]
+			}
+			return executor.ExecuteCheckTLS(cmd.Context(), addr, certFile, caFile, opts, title, exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Box title (default \"TLS Certificate\")")
+	cmd.Flags().StringVar(&certFile, "cert", "", "Inspect a local PEM file (leaf first, then intermediates) instead of connecting")
+	cmd.Flags().StringVar(&caFile, "ca", "", "Verify against the roots in this PEM file instead of the system roots")
+	cmd.Flags().StringVar(&opts.ServerName, "server-name", "", "Name the certificate must be valid for (default: the host connected to)")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", defaultCheckTimeout, "Timeout for connecting and the handshake")
+	cmd.Flags().IntVar(&opts.WarnDays, "warn-days", check.DefaultWarnDays, "Warn when the certificate expires within this many days")
+	cmd.Flags().IntVar(&opts.ErrorDays, "error-days", check.DefaultErrorDays, "Fail when the certificate expires within this many days")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", true, "Exit with code 1 when the check fails")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", true, "Exit with code 2 when the check warns")
+
+	return cmd
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"boxed/internal/box"
+	"boxed/internal/parser"
+	"boxed/internal/view"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+// ConfirmOptions configures ExecuteConfirm.
+type ConfirmOptions struct {
+	Question    string
+	YesLabel    string
+	NoLabel     string
+	DefaultYes  bool
+	Timeout     time.Duration
+	Interactive bool
+}
+
+// ExecuteConfirm renders a warning box with a question and waits for a yes/no answer,
+// exiting 0 when confirmed and 1 otherwise so scripts can use it directly in an if.
+// The answer is read from the controlling terminal, so the prompt still works at the
+// end of a pipeline. Without one (CI, cron) there's nobody to ask, so the default
+// answer is used immediately; it defaults to no, so an unattended run never proceeds
+// by accident.
+func (e *Executor) ExecuteConfirm(opts parser.Options, confirm ConfirmOptions) error {
+	if confirm.Question != "" {
+		opts.Body = confirm.Question
+	}
+	opts.Redactor = e.redactor
+	b, err := parser.ParseBox(string(box.Warning), opts)
+	if err != nil {
+		return err
+	}
+
+	prompt := view.NewConfirm(e.renderer.RenderLines(b), confirm.YesLabel, confirm.NoLabel, confirm.DefaultYes, confirm.Timeout)
+	if confirm.Interactive {
+		program := tea.NewProgram(prompt, tea.WithInputTTY(), tea.WithOutput(e.writer))
+		result, err := program.Run()
+		if err != nil {
+			return fmt.Errorf("prompt failed: %w", err)
+		}
+		prompt = result.(view.Confirm)
+	} else {
+		prompt = prompt.Default("no terminal, using default")
+		if _, err := fmt.Fprint(e.writer, prompt.View()); err != nil {
+			return err
+		}
+	}
+
+	if !prompt.Confirmed() {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// newConfirmCmd creates the confirm subcommand.
+func newConfirmCmd(executor *Executor) *cobra.Command {
+	var opts parser.Options
+	var confirm ConfirmOptions
+	var defaultAnswer string
+
+	cmd := &cobra.Command{
+		Use:   "confirm",
+		Short: "Ask a yes/no question in a warning box",
+		Long: `Render a warning box with a question and read the answer from the terminal: y or n,
+or choose with the arrow keys and press enter. Exits 0 when confirmed and 1 otherwise.
+
+The answer is read from the terminal even when stdin is a pipe. Without a terminal
+(CI, cron), or when --timeout expires, the --default answer is used.`,
+		Example: `  boxed confirm --title "Deploy to prod" --kv "Version=1.4.2" --kv "Region=eu-west-1"
+  boxed confirm --title "Drop table" --question "Really drop users?" --yes-label Drop --no-label Keep
+  boxed confirm --title "Continue?" --default yes --timeout 30s`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch strings.ToLower(defaultAnswer) {
+			case "yes", "y":
+				confirm.DefaultYes = true
+			case "no", "n":
+				confirm.DefaultYes = false
+			default:
+				return fmt.Errorf("invalid default %q: must be yes or no", defaultAnswer)
+			}
+			if confirm.Timeout < 0 {
+				return fmt.Errorf("timeout must not be negative, got %s", confirm.Timeout)
+			}
+
+			confirm.Interactive = hasControllingTerminal()
+			return executor.ExecuteConfirm(opts, confirm)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "", "Box title (bold, centered)")
+	cmd.Flags().StringVarP(&opts.Subtitle, "subtitle", "s", "", "Box subtitle (italic, centered)")
+	cmd.Flags().StringArrayVarP(&opts.KVFlags, "kv", "k", nil, "Key-value pairs (repeatable, format: key=value or key1=value1,key2=value2)")
+	cmd.Flags().StringVarP(&opts.Footer, "footer", "f", "", "Box footer (faint, centered)")
+	cmd.Flags().IntVarP(&opts.Width, "width", "w", 0, "Box width (0 for auto-size)")
+	cmd.Flags().StringVarP(&opts.BorderStyle, "border-style", "b", "rounded", "Border style (normal, rounded, thick, double)")
+	cmd.Flags().StringVarP(&confirm.Question, "question", "q", "Proceed?", "Question shown in the box")
+	cmd.Flags().StringVar(&confirm.YesLabel, "yes-label", "Yes", "Label of the affirmative choice")
+	cmd.Flags().StringVar(&confirm.NoLabel, "no-label", "No", "Label of the negative choice")
+	cmd.Flags().StringVar(&defaultAnswer, "default", "no", "Answer used when there is no terminal or the timeout expires (yes or no)")
+	cmd.Flags().DurationVar(&confirm.Timeout, "timeout", 0, "Use the default answer after this long without input (0 waits forever)")
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	boxio "boxed/internal/io"
+
+	"github.com/spf13/cobra"
+)
+
+// ExecuteContainers reads Docker or Kubernetes JSON from input ("-" for stdin) and
+// renders one row per container or pod.
+func (e *Executor) ExecuteContainers(input, title string, exitOnError, exitOnWarning bool) error {
+	file := os.Stdin
+	name := "stdin"
+	if input != "-" {
+		var err error
+		if file, err = os.Open(input); err != nil {
+			return fmt.Errorf("failed to open input: %w", err)
+		}
+		defer file.Close()
+		name = input
+	}
+
+	report, err := boxio.NewContainersReader(file, name).ReadContainers()
+	if err != nil {
+		return err
+	}
+	return e.printBox(report.Box(title), exitOnError, exitOnWarning)
+}
+
+// newContainersCmd creates the containers subcommand.
+func newContainersCmd(executor *Executor) *cobra.Command {
+	var title string
+	var exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:     "containers [file]",
+		Aliases: []string{"pods"},
+		Short:   "Show container or pod status from Docker or kubectl JSON",
+		Long: `Read container status JSON and show one row per container or pod with its
+state, ready count and restarts, colored by health. Accepted input, detected
+automatically:
+
+  docker ps --format '{{json .}}'     (also docker compose ps --format json)
+  docker inspect <container...>
+  kubectl get pods -o json            (a pod list or a single pod)
+
+Unhealthy, crash-looping and failed entries are errors; starting and pending ones
+are pending; containers that exited with code 0 and completed pods are only
+informational. The box type follows the worst entry. With no file, or "-", the
+JSON is read from stdin.`,
+		Example: `  docker ps -a --format '{{json .}}' | boxed containers
+  docker inspect $(docker ps -q) | boxed containers --title "Web stack"
+  kubectl get pods -A -o json | boxed pods --exit-on-error`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := "-"
+			if len(args) == 1 {
+				input = args[0]
+			}
+			return executor.ExecuteContainers(input, title, exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Box title (default \"Containers\" or \"Pods\")")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", false, "Exit with code 1 when a container or pod is failing")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", false, "Exit with code 2 when the worst entry is a warning")
+
+	return cmd
+}
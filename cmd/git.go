@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"boxed/internal/gitinfo"
+
+	"github.com/spf13/cobra"
+)
+
+// ExecuteGit summarizes the repository containing path. The subtitle is the name
+// of the directory given, which is the repository name for a normal checkout.
+func (e *Executor) ExecuteGit(ctx context.Context, path, title string, exitOnError, exitOnWarning bool) error {
+	summary, err := gitinfo.Read(ctx, path)
+	if err != nil {
+		return err
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	b := gitinfo.SummaryBox(summary, filepath.Base(abs))
+	if title != "" {
+		b.Title = title
+	}
+	return e.printBox(b, exitOnError, exitOnWarning)
+}
+
+// newGitCmd creates the git subcommand.
+func newGitCmd(executor *Executor) *cobra.Command {
+	var title string
+	var exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:   "git [path]",
+		Short: "Summarize a git working tree",
+		Long: `Show the branch, ahead/behind counts against the upstream, staged/modified/untracked
+counts, the last commit and the last tag of the repository at path (default: the
+current directory). The box is a warning when the tree is dirty and an error when
+there are merge conflicts. Only local state is read, so it works offline in CI, and
+the git CLI isn't needed. Credentials in the origin URL are left out.`,
+		Example: `  boxed git
+  boxed git ~/src/project
+  boxed git --exit-on-warning   # fail a CI step when the build left the tree dirty`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "."
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return executor.ExecuteGit(cmd.Context(), path, title, exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Box title (default \"Git Summary\")")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", false, "Exit with code 1 when there are merge conflicts")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", false, "Exit with code 2 when the tree is dirty")
+
+	return cmd
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	boxio "boxed/internal/io"
+	"boxed/internal/live"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// ExecuteGoTest summarizes a `go test -json` stream. With live set, a pending box is
+// redrawn in place as events arrive (at most every tailRedrawInterval); otherwise
+// only the final box is printed.
+func (e *Executor) ExecuteGoTest(ctx context.Context, input io.Reader, title string, live bool, exitOnError bool) error {
+	report := boxio.NewGoTestReport()
+	reader := boxio.NewGoTestReader(input)
+
+	if !live {
+		if err := reader.ReadEvents(report.Add); err != nil {
+			return fmt.Errorf("failed to read test events: %w", err)
+		}
+		b := report.Box(true)
+		if title != "" {
+			b.Title = title
+		}
+		return e.printBox(b, exitOnError, false)
+	}
+
+	return e.animateGoTest(ctx, reader, report, title, exitOnError)
+}
+
+func (e *Executor) animateGoTest(ctx context.Context, reader *boxio.GoTestReader, report *boxio.GoTestReport, title string, exitOnError bool) error {
+	events := make(chan boxio.GoTestEvent)
+	readErr := make(chan error, 1)
+	go func() {
+		readErr <- reader.ReadEvents(func(event boxio.GoTestEvent) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+			}
+		})
+		close(events)
+	}()
+
+	screen := live.NewScreen(e.writer)
+	screen.SetWidth(terminalWidth())
+	if err := screen.HideCursor(); err != nil {
+		return err
+	}
+	defer screen.ShowCursor()
+
+	render := func(done bool) string {
+		b := report.Box(done)
+		if title != "" {
+			b.Title = title
+		}
+		return e.renderer.RenderBox(b)
+	}
+
+	ticker := time.NewTicker(tailRedrawInterval)
+	defer ticker.Stop()
+
+	dirty := true
+	for open := true; open; {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				open = false
+				break
+			}
+			report.Add(event)
+			dirty = true
+		case <-ticker.C:
+			if dirty {
+				if err := screen.Draw(render(false)); err != nil {
+					return err
+				}
+				dirty = false
+			}
+		case <-ctx.Done():
+			open = false
+		}
+	}
+
+	if ctx.Err() == nil {
+		if err := <-readErr; err != nil {
+			return fmt.Errorf("failed to read test events: %w", err)
+		}
+	}
+
+	final := report.Box(true)
+	if title != "" {
+		final.Title = title
+	}
+	if err := screen.Draw(e.renderer.RenderBox(final)); err != nil {
+		return err
+	}
+	screen.ShowCursor()
+	exitForType(final.Type, exitOnError, false)
+	return nil
+}
+
+// newGoTestCmd creates the gotest subcommand.
+func newGoTestCmd(executor *Executor) *cobra.Command {
+	var title string
+	var liveMode, exitOnError bool
+
+	cmd := &cobra.Command{
+		Use:   "gotest",
+		Short: "Summarize `go test -json` output",
+		Long: `Read the event stream of go test -json from stdin and show pass/fail/skip counts
+per package, the total time, and the failing tests with the first lines of their
+output. Any failure (including a build failure) makes it an error box.
+
+With --live the counts update in place while the tests run.`,
+		Example: `  go test -json ./... | boxed gotest
+  go test -json -race ./... 2>&1 | boxed gotest --live`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			liveMode = liveMode && term.IsTerminal(os.Stdout.Fd())
+			return executor.ExecuteGoTest(ctx, os.Stdin, title, liveMode, exitOnError)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Box title (default \"Go Tests\")")
+	cmd.Flags().BoolVar(&liveMode, "live", false, "Update the counts in place while tests run (when stdout is a terminal)")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", true, "Exit with code 1 when any test or build failed")
+
+	return cmd
+}
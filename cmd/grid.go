@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"boxed/internal/box"
+	boxio "boxed/internal/io"
+	"boxed/internal/parser"
+	"boxed/internal/render"
+
+	"github.com/spf13/cobra"
+)
+
+// ExecuteGrid reads box definitions from each input (JSON object, array or NDJSON),
+// parses them like single boxes and renders them as a grid. Inputs are files; "-" or
+// no inputs at all means stdin, so `generate | boxed grid` works without arguments.
+func (e *Executor) ExecuteGrid(inputs []string, layout render.GridLayout) error {
+	if len(inputs) == 0 {
+		inputs = []string{"-"}
+	}
+
+	var boxes []*box.Box
+	for _, input := range inputs {
+		jsonBoxes, err := readJSONBoxes(input)
+		if err != nil {
+			return err
+		}
+
+		parsed, err := e.parseJSONBoxes(jsonBoxes)
+		if err != nil {
+			return fmt.Errorf("%s: %w", input, err)
+		}
+		boxes = append(boxes, parsed...)
+	}
+
+	if len(boxes) == 0 {
+		return fmt.Errorf("no boxes to render: provide JSON files or pipe box definitions to stdin")
+	}
+
+	_, err := fmt.Fprintln(e.writer, render.RenderGrid(e.renderer, boxes, layout))
+	return err
+}
+
+// parseJSONBoxes validates JSON definitions into boxes, each using its own type.
+func (e *Executor) parseJSONBoxes(jsonBoxes []boxio.JSONBox) ([]*box.Box, error) {
+	boxes := make([]*box.Box, 0, len(jsonBoxes))
+	for _, jsonBox := range jsonBoxes {
+		opts, err := jsonBox.Options()
+		if err != nil {
+			return nil, err
+		}
+		opts.Redactor = e.redactor
+		b, err := parser.ParseBox(jsonBox.BoxType(), opts)
+		if err != nil {
+			return nil, err
+		}
+		boxes = append(boxes, b)
+	}
+	return boxes, nil
+}
+
+func readJSONBoxes(input string) ([]boxio.JSONBox, error) {
+	if input == "-" {
+		boxes, err := boxio.NewJSONReader(os.Stdin).ReadBoxes()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JSON from stdin: %w", err)
+		}
+		return boxes, nil
+	}
+
+	file, err := os.Open(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open JSON file: %w", err)
+	}
+	defer file.Close()
+
+	boxes, err := boxio.NewJSONReader(file).ReadBoxes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON from %s: %w", input, err)
+	}
+	return boxes, nil
+}
+
+// newGridCmd creates the grid subcommand. Unlike the per-type commands, each box
+// carries its own "type" in JSON (defaulting to auto) because a dashboard mixes types.
+func newGridCmd(executor *Executor) *cobra.Command {
+	var layout render.GridLayout
+
+	cmd := &cobra.Command{
+		Use:   "grid [file.json...]",
+		Short: "Render several boxes side by side",
+		Long: `Render several boxes as a dashboard grid. Boxes share one width and each row is
+padded to equal height. Rows wrap when the requested columns don't fit the terminal.
+
+Each input may hold a single JSON box, a JSON array of boxes, or NDJSON. Use "-" or
+no arguments to read from stdin. Each box may set "type"; boxes without one use auto.`,
+		Example: `  boxed grid --cols 3 api.json db.json cache.json
+  ./collect-status.sh | boxed grid --cols 2`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if layout.Gap < 0 {
+				return fmt.Errorf("gap must not be negative, got %d", layout.Gap)
+			}
+			if layout.MaxWidth == 0 {
+				layout.MaxWidth = terminalWidth()
+			}
+			return executor.ExecuteGrid(args, layout)
+		},
+	}
+
+	cmd.Flags().IntVarP(&layout.Columns, "cols", "c", 0, "Maximum boxes per row (0 for as many as fit)")
+	cmd.Flags().IntVar(&layout.MaxWidth, "max-width", 0, "Total width available (0 to detect from the terminal)")
+	cmd.Flags().IntVar(&layout.Gap, "gap", 1, "Spaces between boxes in a row")
+
+	return cmd
+}
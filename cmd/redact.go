@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+
+	"boxed/internal/redact"
+	"boxed/internal/render"
+
+	"github.com/spf13/cobra"
+)
+
+// redactFlags holds the redaction flags. They're global since every command prints
+// boxes, and a secret in a check result or test output leaks just the same.
+type redactFlags struct {
+	enable   bool
+	disable  bool
+	patterns []string
+}
+
+func addRedactFlags(cmd *cobra.Command, f *redactFlags) {
+	cmd.PersistentFlags().BoolVar(&f.enable, "redact", false, "Mask secrets in values and text (on by default in CI)")
+	cmd.PersistentFlags().BoolVar(&f.disable, "no-redact", false, "Show values as given, even in CI")
+	cmd.PersistentFlags().StringArrayVar(&f.patterns, "redact-pattern", nil, "Additional regular expression to mask (repeatable; only capture groups are masked if it has any)")
+	cmd.MarkFlagsMutuallyExclusive("redact", "no-redact")
+}
+
+// redactOutput turns on redaction for the executor. Boxes from flags, JSON and
+// templates are masked by parser.ParseBox; the renderer is wrapped as well for the
+// boxes that report readers and live modes build without the parser.
+func (e *Executor) redactOutput(f redactFlags) error {
+	redactor, err := f.redactor()
+	if err != nil || redactor == nil {
+		return err
+	}
+	e.redactor = redactor
+	e.renderer = render.NewRedactingRenderer(e.renderer, redactor)
+	return nil
+}
+
+// redactor returns nil when redaction is off. It's on in CI so secrets don't end up
+// in build logs by accident, when asked for with --redact, and when patterns are
+// given, since passing a pattern only makes sense if it gets applied.
+func (f redactFlags) redactor() (*redact.Redactor, error) {
+	enabled := f.enable || len(f.patterns) > 0 || redact.InCI(os.Getenv)
+	if !enabled || f.disable {
+		return nil, nil
+	}
+	return redact.New(f.patterns)
+}
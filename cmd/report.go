@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	boxio "boxed/internal/io"
+
+	"github.com/spf13/cobra"
+)
+
+// resultsReader is implemented by the JUnit and TAP readers.
+type resultsReader interface {
+	ReadResults() (*boxio.TestResults, error)
+}
+
+// ExecuteTestReport reads every input with newReader, merges the results and
+// renders one summary box. "-" means stdin.
+func (e *Executor) ExecuteTestReport(inputs []string, newReader func(r io.Reader, name string) resultsReader, title string, showFailures, exitOnError, exitOnWarning bool) error {
+	results := &boxio.TestResults{}
+	for _, input := range inputs {
+		file := os.Stdin
+		name := "stdin"
+		if input != "-" {
+			var err error
+			if file, err = os.Open(input); err != nil {
+				return fmt.Errorf("failed to open report: %w", err)
+			}
+			name = input
+		}
+
+		inputResults, err := newReader(file, name).ReadResults()
+		if input != "-" {
+			file.Close()
+		}
+		if err != nil {
+			return err
+		}
+		results.Merge(inputResults)
+	}
+
+	return e.printBox(results.Box(title, showFailures), exitOnError, exitOnWarning)
+}
+
+// newTestReportCmd creates a report subcommand (junit, tap); they differ only in
+// their reader and help text.
+func newTestReportCmd(executor *Executor, use, short, long, example string, newReader func(r io.Reader, name string) resultsReader) *cobra.Command {
+	var title string
+	var showFailures, exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:     use,
+		Short:   short,
+		Long:    long,
+		Example: example,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"-"}
+			}
+			return executor.ExecuteTestReport(args, newReader, title, showFailures, exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "Test Results", "Box title")
+	cmd.Flags().BoolVar(&showFailures, "failures", false, "List failed test cases in a table below the suites")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", true, "Exit with code 1 when any test failed or errored")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", false, "Exit with code 2 when no tests ran or all were skipped")
+
+	return cmd
+}
+
+func newJUnitCmd(executor *Executor) *cobra.Command {
+	return newTestReportCmd(executor,
+		"junit [report.xml...]",
+		"Summarize JUnit XML test reports",
+		`Read JUnit XML reports (Maven Surefire, Gradle, pytest --junitxml, Jest...) and show
+passed/failed/error/skipped counts and the duration of each suite. Several reports
+are merged into one box; with no files, or "-", the report is read from stdin.
+Failures or errors make it an error box.`,
+		`  boxed junit target/surefire-reports/*.xml
+  boxed junit --failures build/test-results/test/*.xml
+  pytest --junitxml=/dev/stdout -q | boxed junit`,
+		func(r io.Reader, name string) resultsReader { return boxio.NewJUnitReader(r, name) },
+	)
+}
+
+func newTAPCmd(executor *Executor) *cobra.Command {
+	return newTestReportCmd(executor,
+		"tap [file...]",
+		"Summarize TAP test output",
+		`Read Test Anything Protocol output (bats, prove, node-tap) and show the counts of
+each stream. Each file is one suite; with no files, or "-", TAP is read from stdin.
+"# SKIP" and "# TODO" tests count as skipped; failures, a "Bail out!" or fewer tests
+than planned make it an error box.`,
+		`  bats --tap test/ | boxed tap --failures
+  boxed tap results/*.tap`,
+		func(r io.Reader, name string) resultsReader { return boxio.NewTAPReader(r, name) },
+	)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package cmd
+
+import "os"
+
+// notifyResize returns a channel that never fires: Windows consoles have no resize
+// signal, so live views pick up the new width on their next redraw instead.
+func notifyResize() (<-chan os.Signal, func()) {
+	return nil, func() {}
+}
@@ -6,10 +6,14 @@ import (
 	"os"
 
 	"boxed/internal/box"
+	"boxed/internal/config"
 	boxio "boxed/internal/io"
 	"boxed/internal/parser"
+	"boxed/internal/redact"
 	"boxed/internal/render"
+	"boxed/internal/rules"
 
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 )
 
@@ -20,6 +24,8 @@ import (
 type Executor struct {
 	renderer render.Renderer
 	writer   io.Writer
+	// redactor is passed to parser.ParseBox; nil when redaction is off.
+	redactor *redact.Redactor
 }
 
 // NewExecutor creates an executor with the given dependencies.
@@ -37,7 +43,13 @@ func NewExecutor(renderer render.Renderer, writer io.Writer) *Executor {
 // This method coordinates the entire pipeline but remains simple because each
 // step is handled by dedicated, well-tested modules. The method itself contains
 // no business logic, just composition of validated components.
-func (e *Executor) Execute(boxType string, opts parser.Options, useStdin bool, useJSON bool, jsonFile string, exitOnError bool, exitOnWarning bool) error {
+//
+// defaults come from config files and fill whatever neither the flags nor the JSON
+// input set, so a user-wide footer never replaces one the input provides.
+//
+// When usePager is set the box opens in the interactive viewer instead of being
+// printed; callers only set it when stdout is a terminal.
+func (e *Executor) Execute(boxType string, opts, defaults parser.Options, useStdin bool, useJSON bool, jsonFile string, exitOnError bool, exitOnWarning bool, usePager bool) error {
 	// JSON input takes precedence over other options
 	if useJSON || jsonFile != "" {
 		var reader *boxio.JSONReader
@@ -57,23 +69,9 @@ func (e *Executor) Execute(boxType string, opts parser.Options, useStdin bool, u
 			return fmt.Errorf("failed to read JSON: %w", err)
 		}
 
-		// Merge JSON options with CLI options (CLI takes precedence for overrides)
-		if opts.Title == "" {
-			opts.Title = jsonOpts.Title
-		}
-		if opts.Subtitle == "" {
-			opts.Subtitle = jsonOpts.Subtitle
-		}
-		if opts.Footer == "" {
-			opts.Footer = jsonOpts.Footer
-		}
-		if opts.Width == 0 {
-			opts.Width = jsonOpts.Width
-		}
-		if opts.BorderStyle == "" {
-			opts.BorderStyle = jsonOpts.BorderStyle
-		}
-		opts.KVFlags = append(opts.KVFlags, jsonOpts.KVFlags...)
+		// JSON is the layer below the flags: flags that were given win, and a --kv
+		// key replaces the JSON row of the same key.
+		opts = config.Merge(opts, jsonOpts)
 	} else if useStdin {
 		reader := boxio.NewStdinKVReader(os.Stdin)
 		stdinKVs, err := reader.ReadKVPairs()
@@ -86,27 +84,46 @@ func (e *Executor) Execute(boxType string, opts parser.Options, useStdin bool, u
 		}
 	}
 
-	b, err := parser.ParseBox(boxType, opts)
+	opts.Redactor = e.redactor
+	b, err := parser.ParseBox(boxType, config.Merge(opts, defaults))
 	if err != nil {
 		return err
 	}
+	if b.Layout.Columns == box.ColumnsAuto || b.Layout.Columns > 1 {
+		b.Layout.MaxWidth = terminalWidth() - render.FrameWidth
+	}
 
-	output := e.renderer.RenderBox(b)
+	if usePager {
+		if err := e.ExecuteView([]*box.Box{b}); err != nil {
+			return err
+		}
+		exitForType(b.Type, exitOnError, exitOnWarning)
+		return nil
+	}
 
-	_, err = fmt.Fprintln(e.writer, output)
-	if err != nil {
+	return e.printBox(b, exitOnError, exitOnWarning)
+}
+
+// printBox renders a finished box and applies the exit code flags. Commands that build
+// their box from collected data (checks, report readers) share it so --exit-on-error
+// and --exit-on-warning mean the same thing everywhere.
+func (e *Executor) printBox(b *box.Box, exitOnError, exitOnWarning bool) error {
+	if _, err := fmt.Fprintln(e.writer, e.renderer.RenderBox(b)); err != nil {
 		return err
 	}
+	exitForType(b.Type, exitOnError, exitOnWarning)
+	return nil
+}
 
-	// Exit with non-zero code based on box type if flags are set
-	if exitOnError && b.Type == box.Error {
+// exitForType exits with code 1 for error boxes and 2 for warning boxes when the
+// corresponding flag is set.
+func exitForType(t box.BoxType, exitOnError, exitOnWarning bool) {
+	if exitOnError && t == box.Error {
 		os.Exit(1)
 	}
-	if exitOnWarning && b.Type == box.Warning {
+	if exitOnWarning && t == box.Warning {
 		os.Exit(2)
 	}
-
-	return nil
 }
 
 // NewRootCmd creates the root cobra command with all subcommands configured.
@@ -115,6 +132,7 @@ func (e *Executor) Execute(boxType string, opts parser.Options, useStdin bool, u
 // "boxed success ..." rather than "boxed --type success ...", which reads more
 // naturally and follows common CLI patterns.
 func NewRootCmd(executor *Executor) *cobra.Command {
+	var redaction redactFlags
 	rootCmd := &cobra.Command{
 		Use:   "boxed",
 		Short: "Render beautiful bordered boxes for terminal output",
@@ -122,19 +140,28 @@ func NewRootCmd(executor *Executor) *cobra.Command {
 titles, subtitles, key-value pairs, and footers. Perfect for deployment scripts,
 CI/CD pipelines, and any command-line tool that needs clear visual status output.`,
 		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return executor.redactOutput(redaction)
+		},
 	}
+	addRedactFlags(rootCmd, &redaction)
 
-	makeBoxCmd := func(boxType box.BoxType) *cobra.Command {
-		var title, subtitle, footer, borderStyle string
-		var kvFlags []string
+	makeBoxCmd := func(boxType string, short, long string) *cobra.Command {
+		var title, subtitle, body, footer, borderStyle, locale, columns string
+		var layout box.KVLayout
+		var kvFlags, ruleFlags []string
 		var width int
-		var useStdin, useJSON, exitOnError, exitOnWarning bool
-		var jsonFile string
+		var useStdin, useJSON, exitOnError, exitOnWarning, usePager, alignNumbers, compact, raw bool
+		var jsonFile, rulesFile, preset string
+
+		// The auto type exists to turn rule results into exit codes, so it enables
+		// both exit flags by default; they can still be disabled explicitly.
+		exitByDefault := boxType == parser.AutoType
 
 		cmd := &cobra.Command{
-			Use:   string(boxType),
-			Short: fmt.Sprintf("Render a %s box", boxType),
-			Long:  fmt.Sprintf("Render a %s box with %s border color", boxType, getColorName(boxType)),
+			Use:   boxType,
+			Short: short,
+			Long:  long,
 			Example: fmt.Sprintf(`  boxed %s --title "Deploy Complete"
   boxed %s --title "Build v2.1.0" --kv "Duration=2m 34s" --kv "Commit=abc1234"
   boxed %s --title "Status" --subtitle "Production" --footer "Updated 2025-10-19"
@@ -147,41 +174,143 @@ CI/CD pipelines, and any command-line tool that needs clear visual status output
 					Title:       title,
 					Subtitle:    subtitle,
 					KVFlags:     kvFlags,
+					Body:        body,
 					Footer:      footer,
 					Width:       width,
 					BorderStyle: borderStyle,
+					Layout:      layout,
+					Columns:     columns,
+					Rules:       ruleFlags,
+					Locale:      locale,
+				}
+				// Switches only count when given, so --compact=false can turn off a
+				// compact layout from JSON or a config file.
+				if cmd.Flags().Changed("align-numbers") {
+					opts.AlignNumbers = &alignNumbers
+				}
+				if cmd.Flags().Changed("compact") {
+					opts.Compact = &compact
+				}
+				if cmd.Flags().Changed("raw") {
+					opts.Raw = &raw
+				}
+
+				if rulesFile != "" {
+					fileRules, err := readRulesFile(rulesFile)
+					if err != nil {
+						return err
+					}
+					opts.Rules = append(fileRules, opts.Rules...)
 				}
 
-				return executor.Execute(string(boxType), opts, useStdin, useJSON, jsonFile, exitOnError, exitOnWarning)
+				defaults, err := loadDefaults(preset)
+				if err != nil {
+					return err
+				}
+				// The flag's "rounded" default must not hide a configured style.
+				if !cmd.Flags().Changed("border-style") {
+					opts.BorderStyle = ""
+				}
+
+				usePager = usePager && term.IsTerminal(os.Stdout.Fd())
+				return executor.Execute(boxType, opts, defaults, useStdin, useJSON, jsonFile, exitOnError, exitOnWarning, usePager)
 			},
 		}
 
 		cmd.Flags().StringVarP(&title, "title", "t", "", "Box title (bold, centered)")
 		cmd.Flags().StringVarP(&subtitle, "subtitle", "s", "", "Box subtitle (italic, centered)")
 		cmd.Flags().StringArrayVarP(&kvFlags, "kv", "k", nil, "Key-value pairs (repeatable, format: key=value or key1=value1,key2=value2)")
+		cmd.Flags().StringVar(&body, "body", "", "Free-form text shown below the KV pairs (line breaks are kept)")
 		cmd.Flags().StringVarP(&footer, "footer", "f", "", "Box footer (faint, centered)")
 		cmd.Flags().IntVarP(&width, "width", "w", 0, "Box width (0 for auto-size)")
 		cmd.Flags().StringVarP(&borderStyle, "border-style", "b", "rounded", "Border style (normal, rounded, thick, double)")
+		cmd.Flags().StringVar(&layout.KeyAlign, "key-align", "", "Align keys to the left or right")
+		cmd.Flags().StringVar(&layout.Separator, "separator", "", `Separator between keys and values, e.g. ":" or "→" ("dots" for dotted leaders)`)
+		cmd.Flags().BoolVar(&alignNumbers, "align-numbers", false, "Right-align values that start with a number")
+		cmd.Flags().BoolVar(&compact, "compact", false, "Don't put a blank line between KV pairs")
+		cmd.Flags().BoolVar(&raw, "raw", false, "Show text as written, without inline styling such as **bold** or [links](url)")
+		cmd.Flags().StringVar(&columns, "columns", "", `Flow KV pairs into N side-by-side columns ("auto" to fit the terminal)`)
 		cmd.Flags().BoolVar(&useStdin, "stdin-kv", false, "Read additional KV pairs from stdin (one per line)")
 		cmd.Flags().BoolVar(&useJSON, "json", false, "Read box definition from JSON stdin")
 		cmd.Flags().StringVar(&jsonFile, "json-file", "", "Read box definition from JSON file")
-		cmd.Flags().BoolVar(&exitOnError, "exit-on-error", false, "Exit with code 1 when rendering an error box")
-		cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", false, "Exit with code 2 when rendering a warning box")
+		cmd.Flags().StringArrayVar(&ruleFlags, "rule", nil, "Status rule evaluated against KV values (repeatable, format: key<op>value:status, e.g. CPU>90:error)")
+		cmd.Flags().StringVar(&rulesFile, "rules-file", "", "Read status rules from a file (one per line, # for comments)")
+		cmd.Flags().BoolVar(&exitOnError, "exit-on-error", exitByDefault, "Exit with code 1 when rendering an error box")
+		cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", exitByDefault, "Exit with code 2 when rendering a warning box")
+		cmd.Flags().BoolVar(&usePager, "pager", false, "Open the box in an interactive viewer with scrolling and search (when stdout is a terminal)")
+		cmd.Flags().StringVar(&locale, "locale", "", "Number separators for typed values such as @bytes: and @number: (e.g. de, fr, en-US)")
+		cmd.Flags().StringVar(&preset, "preset", "", "Apply a named preset from the config file")
 		cmd.MarkFlagsMutuallyExclusive("stdin-kv", "json", "json-file")
 
 		return cmd
 	}
 
+	for _, boxType := range box.AllBoxTypes() {
+		rootCmd.AddCommand(makeBoxCmd(
+			string(boxType),
+			fmt.Sprintf("Render a %s box", boxType),
+			fmt.Sprintf("Render a %s box with %s border color", boxType, getColorName(boxType)),
+		))
+	}
+
+	rootCmd.AddCommand(makeBoxCmd(
+		parser.AutoType,
+		"Render a box whose type is derived from rules",
+		`Render a box whose type is the most severe status produced by --rule/--rules-file
+and per-row statuses, defaulting to success. Exits with code 1 for error boxes and
+2 for warning boxes unless --exit-on-error=false or --exit-on-warning=false is given.`,
+	))
+
 	rootCmd.AddCommand(
-		makeBoxCmd(box.Success),
-		makeBoxCmd(box.Error),
-		makeBoxCmd(box.Info),
-		makeBoxCmd(box.Warning),
+		newGridCmd(executor),
+		newWatchCmd(executor),
+		newSpinCmd(executor),
+		newTailCmd(executor),
+		newViewCmd(executor),
+		newConfirmCmd(executor),
+		newCheckCmd(executor),
+		newGitCmd(executor),
+		newGoTestCmd(executor),
+		newJUnitCmd(executor),
+		newTAPCmd(executor),
+		newSarifCmd(executor),
+		newContainersCmd(executor),
+		newTmplCmd(executor),
 	)
 
 	return rootCmd
 }
 
+// loadDefaults resolves the config file layers below the command line. Precedence,
+// highest first: flags, the --preset, the project .boxed.yaml, the user config.
+func loadDefaults(preset string) (parser.Options, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return parser.Options{}, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return parser.Options{}, err
+	}
+	return cfg.Resolve(preset)
+}
+
+// readRulesFile loads rule specs from disk. Parsing is left to parser.ParseBox so
+// file and flag rules are validated in one place.
+func readRulesFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rules file: %w", err)
+	}
+	defer file.Close()
+
+	specs, err := rules.Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules file: %w", err)
+	}
+	return specs, nil
+}
+
 func getColorName(t box.BoxType) string {
 	switch t {
 	case box.Success:
@@ -192,6 +321,8 @@ func getColorName(t box.BoxType) string {
 		return "blue"
 	case box.Warning:
 		return "yellow"
+	case box.Pending:
+		return "purple"
 	default:
 		return "default"
 	}
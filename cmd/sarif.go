@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	boxio "boxed/internal/io"
+
+	"github.com/spf13/cobra"
+)
+
+// ExecuteFindings reads static-analysis reports (SARIF or golangci-lint JSON) and
+// renders one summary box for all of them. "-" means stdin.
+func (e *Executor) ExecuteFindings(inputs []string, title string, limit int, exitOnError, exitOnWarning bool) error {
+	var findings []boxio.Finding
+	for _, input := range inputs {
+		file := os.Stdin
+		name := "stdin"
+		if input != "-" {
+			var err error
+			if file, err = os.Open(input); err != nil {
+				return fmt.Errorf("failed to open report: %w", err)
+			}
+			name = input
+		}
+
+		inputFindings, err := boxio.NewFindingsReader(file, name).ReadFindings()
+		if input != "-" {
+			file.Close()
+		}
+		if err != nil {
+			return err
+		}
+		findings = append(findings, inputFindings...)
+	}
+
+	return e.printBox(boxio.FindingsBox(findings, title, limit), exitOnError, exitOnWarning)
+}
+
+// newSarifCmd creates the sarif subcommand.
+func newSarifCmd(executor *Executor) *cobra.Command {
+	var title string
+	var limit int
+	var exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:     "sarif [report...]",
+		Aliases: []string{"lint"},
+		Short:   "Summarize static-analysis findings (SARIF, golangci-lint JSON)",
+		Long: `Read static-analysis reports and show finding counts by severity, the rules and
+files with the most findings, and a table of the first findings with file:line.
+SARIF files (CodeQL, gosec, semgrep, staticcheck...) and golangci-lint JSON output
+are detected automatically; several reports are merged into one box. With no files,
+or "-", the report is read from stdin. The box type follows the highest severity.`,
+		Example: `  boxed sarif results.sarif
+  golangci-lint run --out-format json | boxed lint
+  boxed sarif gosec.sarif semgrep.sarif --limit 20`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 {
+				return fmt.Errorf("limit must not be negative, got %d", limit)
+			}
+			if len(args) == 0 {
+				args = []string{"-"}
+			}
+			return executor.ExecuteFindings(args, title, limit, exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "Static Analysis", "Box title")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 10, "Number of findings listed in the table (0 for none)")
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", true, "Exit with code 1 when there are error findings")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", false, "Exit with code 2 when the worst findings are warnings")
+
+	return cmd
+}
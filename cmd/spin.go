@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"boxed/internal/live"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// spinnerInterval is fast enough to look animated without redrawing the whole box
+// more often than terminals can comfortably repaint.
+const spinnerInterval = 100 * time.Millisecond
+
+// ExecuteSpin runs a command while showing an animated pending box, then replaces it
+// with a success or error box carrying the exit status and duration. The command's
+// output is captured rather than streamed, since it would tear through the redrawn
+// box; the tail is shown in the final box when the command fails. When interactive is
+// false (stdout isn't a terminal), only the final box is printed. A failing command's
+// exit code is propagated so scripts can still branch on it.
+func (e *Executor) ExecuteSpin(ctx context.Context, title string, command []string, interactive bool) error {
+	if len(command) == 0 {
+		return fmt.Errorf("no command given: usage is boxed spin [flags] -- <command> [args...]")
+	}
+
+	// exec serializes writes when Stdout and Stderr are the same writer, so one
+	// buffer keeps the interleaving the user would have seen in a terminal.
+	var output bytes.Buffer
+	c := exec.CommandContext(ctx, command[0], command[1:]...)
+	c.Stdout = &output
+	c.Stderr = &output
+
+	start := time.Now()
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- c.Wait() }()
+
+	screen := live.NewScreen(e.writer)
+	var runErr error
+	if interactive {
+		if err := screen.HideCursor(); err != nil {
+			return err
+		}
+		runErr = e.animateSpin(screen, title, command, start, done)
+	} else {
+		runErr = <-done
+	}
+
+	final, exitCode := live.ResultBox(title, command, time.Since(start), runErr, output.String(), ctx.Err() != nil)
+	if interactive {
+		screen.SetWidth(terminalWidth())
+		err := screen.Draw(e.renderer.RenderBox(final))
+		screen.ShowCursor()
+		if err != nil {
+			return err
+		}
+	} else if _, err := fmt.Fprintln(e.writer, e.renderer.RenderBox(final)); err != nil {
+		return err
+	}
+
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+	return nil
+}
+
+// animateSpin redraws the pending box until the command finishes, returning its result.
+// If the terminal stops accepting output, animation stops but the command is still
+// awaited so its result isn't lost.
+func (e *Executor) animateSpin(screen *live.Screen, title string, command []string, start time.Time, done <-chan error) error {
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		pending := live.PendingBox(title, command, frame, time.Since(start))
+		screen.SetWidth(terminalWidth())
+		if err := screen.Draw(e.renderer.RenderBox(pending)); err != nil {
+			return <-done
+		}
+
+		select {
+		case err := <-done:
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// newSpinCmd creates the spin subcommand.
+func newSpinCmd(executor *Executor) *cobra.Command {
+	var title string
+
+	cmd := &cobra.Command{
+		Use:   "spin [flags] -- <command> [args...]",
+		Short: "Show a pending box while a command runs",
+		Long: `Run a command while showing an animated pending box with the elapsed time, then
+replace it with a success or error box carrying the exit status and duration. The
+command's output is captured and its tail is shown when it fails. When stdout is not
+a terminal, only the final box is printed. Exits with the command's exit code.`,
+		Example: `  boxed spin --title "Deploying" -- ./deploy.sh
+  boxed spin -t "Running tests" -- go test ./...`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			interactive := term.IsTerminal(os.Stdout.Fd())
+			return executor.ExecuteSpin(ctx, title, args, interactive)
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "Running", "Box title")
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
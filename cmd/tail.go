@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"syscall"
+	"time"
+
+	"boxed/internal/box"
+	"boxed/internal/live"
+	"boxed/internal/render"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// tailRedrawInterval caps redraws for chatty streams; drawing on every line would
+// spend more time repainting than the stream spends producing output.
+const tailRedrawInterval = 50 * time.Millisecond
+
+// maxTailLineBytes allows long log lines (minified JSON, stack traces) without the
+// scanner failing on its 64KB default.
+const maxTailLineBytes = 1024 * 1024
+
+// TailOptions configures ExecuteTail.
+type TailOptions struct {
+	Title          string
+	Lines          int
+	ErrorPattern   *regexp.Regexp
+	WarningPattern *regexp.Regexp
+	Interactive    bool
+	ExitOnError    bool
+}
+
+// ExecuteTail reads lines from input and keeps a fixed-height box showing the last
+// lines, redrawn in place as new lines arrive. When the stream ends, the final box is
+// left on screen (or, when not interactive, printed once).
+func (e *Executor) ExecuteTail(ctx context.Context, input io.Reader, opts TailOptions) error {
+	if opts.Lines <= 0 {
+		return fmt.Errorf("lines must be positive, got %d", opts.Lines)
+	}
+
+	// Lines are truncated rather than wrapped so the box keeps a fixed height.
+	lineWidth := min(terminalWidth()-render.FrameWidth, render.MaxContentWidth)
+	tail := live.NewTail(opts.Lines, opts.ErrorPattern, opts.WarningPattern)
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(input)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxTailLineBytes)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				readErr <- nil
+				return
+			}
+		}
+		close(lines)
+		readErr <- scanner.Err()
+	}()
+
+	screen := live.NewScreen(e.writer)
+	screen.SetWidth(terminalWidth())
+	if opts.Interactive {
+		if err := screen.HideCursor(); err != nil {
+			return err
+		}
+		defer screen.ShowCursor()
+	}
+
+	resized, stopResize := notifyResize()
+	defer stopResize()
+
+	ticker := time.NewTicker(tailRedrawInterval)
+	defer ticker.Stop()
+
+	dirty := opts.Interactive
+	for open := true; open; {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				open = false
+				break
+			}
+			tail.Add(line)
+			dirty = opts.Interactive
+		case <-resized:
+			width := terminalWidth()
+			lineWidth = min(width-render.FrameWidth, render.MaxContentWidth)
+			if opts.Interactive {
+				// The terminal reflowed the old frame, so it's redrawn from scratch
+				// with lines truncated to the new width.
+				screen.SetWidth(width)
+				if err := screen.Reset(); err != nil {
+					return err
+				}
+				dirty = true
+			}
+		case <-ticker.C:
+			if dirty {
+				if err := screen.Draw(e.renderer.RenderBox(tail.Box(opts.Title, lineWidth, false))); err != nil {
+					return err
+				}
+				dirty = false
+			}
+		case <-ctx.Done():
+			open = false
+		}
+	}
+
+	if ctx.Err() == nil {
+		if err := <-readErr; err != nil {
+			return fmt.Errorf("failed to read input: %w", err)
+		}
+	}
+
+	final := tail.Box(opts.Title, lineWidth, true)
+	if opts.Interactive {
+		if err := screen.Draw(e.renderer.RenderBox(final)); err != nil {
+			return err
+		}
+	} else if _, err := fmt.Fprintln(e.writer, e.renderer.RenderBox(final)); err != nil {
+		return err
+	}
+
+	if opts.ExitOnError && final.Type == box.Error {
+		screen.ShowCursor()
+		os.Exit(1)
+	}
+	return nil
+}
+
+// newTailCmd creates the tail subcommand.
+func newTailCmd(executor *Executor) *cobra.Command {
+	var opts TailOptions
+	var errorPattern, warningPattern string
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Show the last lines of a stream in a live box",
+		Long: `Read a stream from stdin and keep a fixed-height box showing the last lines,
+redrawn in place as new lines arrive. Lines matching --error-pattern switch the box to
+error, lines matching --warning-pattern to warning. When the stream ends the final box
+is printed normally: success if nothing matched.`,
+		Example: `  make build 2>&1 | boxed tail --lines 15 --title "Build output"
+  kubectl logs -f deploy/api | boxed tail --error-pattern 'panic|OOMKilled'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if opts.ErrorPattern, err = compileOptionalPattern("error-pattern", errorPattern); err != nil {
+				return err
+			}
+			if opts.WarningPattern, err = compileOptionalPattern("warning-pattern", warningPattern); err != nil {
+				return err
+			}
+			opts.Interactive = term.IsTerminal(os.Stdout.Fd())
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return executor.ExecuteTail(ctx, os.Stdin, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Title, "title", "t", "Output", "Box title")
+	cmd.Flags().IntVarP(&opts.Lines, "lines", "n", 10, "Number of lines to show")
+	cmd.Flags().StringVar(&errorPattern, "error-pattern", `(?i)\b(error|fatal|panic)\b`, "Regular expression for lines that make the box an error (empty to disable)")
+	cmd.Flags().StringVar(&warningPattern, "warning-pattern", `(?i)\bwarn(ing)?\b`, "Regular expression for lines that make the box a warning (empty to disable)")
+	cmd.Flags().BoolVar(&opts.ExitOnError, "exit-on-error", false, "Exit with code 1 when the final box is an error box")
+
+	return cmd
+}
+
+func compileOptionalPattern(flag, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --%s: %w", flag, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// defaultTerminalWidth is used when output isn't a terminal and COLUMNS is unset,
+// matching the width most CI log viewers wrap at.
+const defaultTerminalWidth = 80
+
+// hasControllingTerminal reports whether prompts can reach the user. It checks the
+// controlling terminal rather than stdin, which is a pipe in `cmd | boxed confirm`
+// while the user is still sitting at the terminal.
+func hasControllingTerminal() bool {
+	tty, err := os.Open(ttyPath)
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+	return term.IsTerminal(tty.Fd())
+}
+
+// terminalWidth reports the width available on stdout. COLUMNS is honored as a
+// fallback so piped output (e.g. under `watch` or in CI) can still be sized.
+func terminalWidth() int {
+	if width, _, err := term.GetSize(os.Stdout.Fd()); err == nil && width > 0 {
+		return width
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultTerminalWidth
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"boxed/internal/templates"
+
+	"github.com/spf13/cobra"
+)
+
+// ExecuteTemplate renders the named template with "name=value" arguments. Config
+// file defaults still apply below the template, so a user-wide border style or
+// width carries over.
+func (e *Executor) ExecuteTemplate(name string, args []string, exitOnError, exitOnWarning bool) error {
+	dirs, err := templateDirs()
+	if err != nil {
+		return err
+	}
+	t, err := templates.Find(dirs, name)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid template argument %q, must be name=value (usage: boxed tmpl %s)", arg, t.Usage())
+		}
+		values[key] = value
+	}
+
+	boxType, opts, err := t.Render(values)
+	if err != nil {
+		return err
+	}
+	defaults, err := loadDefaults("")
+	if err != nil {
+		return err
+	}
+	return e.Execute(boxType, opts, defaults, false, false, "", exitOnError, exitOnWarning, false)
+}
+
+// ExecuteTemplateList shows the templates found in the template directories.
+func (e *Executor) ExecuteTemplateList() error {
+	dirs, err := templateDirs()
+	if err != nil {
+		return err
+	}
+	found, err := templates.Discover(dirs)
+	if err != nil {
+		return err
+	}
+	return e.printBox(templates.ListBox(found, dirs), false, false)
+}
+
+func templateDirs() ([]string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return templates.Dirs(dir, os.Getenv), nil
+}
+
+// newTmplCmd creates the tmpl subcommand and its list subcommand.
+func newTmplCmd(executor *Executor) *cobra.Command {
+	var exitOnError, exitOnWarning bool
+
+	cmd := &cobra.Command{
+		Use:     "tmpl <name> [variable=value...]",
+		Aliases: []string{"template"},
+		Short:   "Render a saved box template",
+		Long: `Render a box from a template file: a YAML layout with the type, title, ordered
+KV rows, body and footer, where {{name}} placeholders are filled from the
+variable=value arguments. Variables listed under "defaults" are optional; all
+missing required variables are reported together.
+
+Templates are .yaml files found in, first match wins:
+
+  $BOXED_TEMPLATES              (a path list)
+  .boxed/templates              (in the current directory or a parent)
+  $XDG_CONFIG_HOME/boxed/templates
+
+Run "boxed tmpl list" to see the available templates and their variables.`,
+		Example: `  boxed tmpl deploy version=1.2 env=prod
+  boxed tmpl list`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executor.ExecuteTemplate(args[0], args[1:], exitOnError, exitOnWarning)
+		},
+	}
+
+	cmd.Flags().BoolVar(&exitOnError, "exit-on-error", false, "Exit with code 1 when rendering an error box")
+	cmd.Flags().BoolVar(&exitOnWarning, "exit-on-warning", false, "Exit with code 2 when rendering a warning box")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List available templates and their variables",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executor.ExecuteTemplateList()
+		},
+	})
+
+	return cmd
+}
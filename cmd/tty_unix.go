@@ -0,0 +1,6 @@
+//go:build !windows
+
+package cmd
+
+// ttyPath is the controlling terminal, which Bubble Tea's WithInputTTY reads from.
+const ttyPath = "/dev/tty"
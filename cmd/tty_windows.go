@@ -0,0 +1,6 @@
+//go:build windows
+
+package cmd
+
+// ttyPath is the console input, which Bubble Tea's WithInputTTY reads from.
+const ttyPath = "CONIN$"
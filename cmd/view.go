@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"boxed/internal/box"
+	"boxed/internal/view"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// ExecuteView shows boxes in an interactive full-screen viewer, stacked vertically.
+// Input is read from the controlling terminal rather than stdin so the viewer still
+// works when the box definition itself was piped in.
+func (e *Executor) ExecuteView(boxes []*box.Box) error {
+	var lines []string
+	var lineKVs [][]box.KV
+	for i, b := range boxes {
+		if i > 0 {
+			lines = append(lines, "")
+			lineKVs = append(lineKVs, nil)
+		}
+		boxLines, boxKVs := e.renderer.RenderLinesWithKVs(b)
+		lines = append(lines, boxLines...)
+		lineKVs = append(lineKVs, boxKVs...)
+	}
+
+	model := view.New(lines, lineKVs, copyToClipboard)
+	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithInputTTY(), tea.WithOutput(e.writer))
+	if _, err := program.Run(); err != nil {
+		return fmt.Errorf("viewer failed: %w", err)
+	}
+	return nil
+}
+
+// copyToClipboard uses OSC 52 so copying works over SSH and without a clipboard
+// utility. It writes to stderr to stay out of the way of the viewer's own output.
+func copyToClipboard(value string) error {
+	seq := osc52.New(value)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+	_, err := seq.WriteTo(os.Stderr)
+	return err
+}
+
+// newViewCmd creates the view subcommand.
+func newViewCmd(executor *Executor) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view [file.json...]",
+		Short: "Browse large boxes in an interactive viewer",
+		Long: `Show boxes in a full-screen viewer with scrolling, search and copy. Input is the
+same as for grid: JSON objects, arrays or NDJSON from files or stdin ("-").
+
+Keys: ↑/↓ or j/k move, PgUp/PgDn page, g/G top/bottom, / search, n/N next/previous
+match, y copy the selected value, q quit.`,
+		Example: `  boxed view report.json
+  ./inventory.sh | boxed view`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				args = []string{"-"}
+			}
+
+			var boxes []*box.Box
+			for _, input := range args {
+				jsonBoxes, err := readJSONBoxes(input)
+				if err != nil {
+					return err
+				}
+				parsed, err := executor.parseJSONBoxes(jsonBoxes)
+				if err != nil {
+					return fmt.Errorf("%s: %w", input, err)
+				}
+				boxes = append(boxes, parsed...)
+			}
+			if len(boxes) == 0 {
+				return fmt.Errorf("no boxes to view: provide JSON files or pipe box definitions to stdin")
+			}
+
+			if !term.IsTerminal(os.Stdout.Fd()) {
+				for _, b := range boxes {
+					if _, err := fmt.Fprintln(executor.writer, executor.renderer.RenderBox(b)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			return executor.ExecuteView(boxes)
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"boxed/internal/box"
+	boxio "boxed/internal/io"
+	"boxed/internal/live"
+	"boxed/internal/render"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultWatchTimeout bounds a single run so a hung command can't freeze the loop.
+const defaultWatchTimeout = 30 * time.Second
+
+// ExecuteWatch re-runs a command that prints JSON box definitions and redraws the result
+// in place every interval until ctx is cancelled. Failures are rendered as error boxes
+// rather than ending the loop, because a transient failure is exactly what someone
+// watching a dashboard wants to see. Each run is killed after timeout for the same
+// reason: a command that hangs would otherwise stop the redraws and resize handling.
+func (e *Executor) ExecuteWatch(ctx context.Context, command []string, interval, timeout time.Duration) error {
+	if len(command) == 0 {
+		return fmt.Errorf("no command given: usage is boxed watch [flags] -- <command> [args...]")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("interval must be positive, got %s", interval)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("timeout must be positive, got %s", timeout)
+	}
+
+	screen := live.NewScreen(e.writer)
+	if err := screen.HideCursor(); err != nil {
+		return err
+	}
+	defer screen.ShowCursor()
+
+	resized, stopResize := notifyResize()
+	defer stopResize()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	boxes := e.runWatchCommand(ctx, command, interval, timeout)
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		width := terminalWidth()
+		screen.SetWidth(width)
+		if err := screen.Draw(e.renderBoxes(boxes, width)); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-resized:
+			if err := screen.Reset(); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			boxes = e.runWatchCommand(ctx, command, interval, timeout)
+		}
+	}
+}
+
+// runWatchCommand runs the command once and converts its output into boxes stamped
+// with the refresh time.
+func (e *Executor) runWatchCommand(ctx context.Context, command []string, interval, timeout time.Duration) []*box.Box {
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(runCtx, command[0], command[1:]...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	// Children that inherited the output pipes would keep Run waiting after the kill.
+	c.WaitDelay = time.Second
+
+	var boxes []*box.Box
+	if err := c.Run(); runCtx.Err() == context.DeadlineExceeded {
+		boxes = []*box.Box{live.CommandErrorBox("Command timed out", command, fmt.Errorf("no result after %s", timeout), stderr.String())}
+	} else if err != nil {
+		boxes = []*box.Box{live.CommandErrorBox("Command failed", command, err, stderr.String())}
+	} else if jsonBoxes, err := boxio.NewJSONReader(&stdout).ReadBoxes(); err != nil {
+		boxes = []*box.Box{live.CommandErrorBox("Invalid box definition", command, err, stderr.String())}
+	} else if parsed, err := e.parseJSONBoxes(jsonBoxes); err != nil {
+		boxes = []*box.Box{live.CommandErrorBox("Invalid box definition", command, err, stderr.String())}
+	} else if len(parsed) == 0 {
+		boxes = []*box.Box{live.CommandErrorBox("No output", command, fmt.Errorf("command printed no box definitions"), stderr.String())}
+	} else {
+		boxes = parsed
+	}
+
+	stamp := fmt.Sprintf("Updated %s • every %s", time.Now().Format("15:04:05"), interval)
+	for _, b := range boxes {
+		if b.Footer == "" {
+			b.Footer = stamp
+		} else {
+			b.Footer += " • " + stamp
+		}
+	}
+
+	return boxes
+}
+
+// renderBoxes renders one box on its own or several as a grid fitted to width.
+func (e *Executor) renderBoxes(boxes []*box.Box, width int) string {
+	if len(boxes) == 1 {
+		return e.renderer.RenderBox(boxes[0])
+	}
+	return render.RenderGrid(e.renderer, boxes, render.GridLayout{MaxWidth: width, Gap: 1})
+}
+
+// newWatchCmd creates the watch subcommand.
+func newWatchCmd(executor *Executor) *cobra.Command {
+	var interval, timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch [flags] -- <command> [args...]",
+		Short: "Re-run a command and redraw its box in place",
+		Long: `Re-run a command every interval and redraw the box it prints in place, without
+scrolling or flicker. The command must print a JSON box definition (or several, which
+are shown as a grid); boxes without a "type" use auto. Failures are shown as an error
+box and the command is retried on the next tick; so is a run that exceeds --timeout.
+Press Ctrl-C to stop.`,
+		Example: `  boxed watch --interval 5s -- ./examples/system-monitor-json.sh
+  boxed watch -n 10s -- kubectl-status --json`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return executor.ExecuteWatch(ctx, args, interval, timeout)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&interval, "interval", "n", 2*time.Second, "Time between runs")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultWatchTimeout, "Kill a run that takes longer than this")
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
@@ -20,9 +20,10 @@ status="$1"
 backup_file="$2"
 destination="${3:-local}"
 
+# Sizes and ages are passed as typed values; boxed formats them ("2.4 GiB", "3h ago").
 get_file_size() {
     if [ -f "$1" ]; then
-        du -h "$1" | cut -f1
+        echo "@bytes:$(stat -c %s "$1" 2>/dev/null || stat -f %z "$1" 2>/dev/null)"
     else
         echo "N/A"
     fi
@@ -30,17 +31,7 @@ get_file_size() {
 
 get_file_age() {
     if [ -f "$1" ]; then
-        local mod_time=$(stat -c %Y "$1" 2>/dev/null || stat -f %m "$1" 2>/dev/null)
-        local current_time=$(date +%s)
-        local age=$((current_time - mod_time))
-        local hours=$((age / 3600))
-        local minutes=$(((age % 3600) / 60))
-
-        if [ "$hours" -gt 0 ]; then
-            echo "${hours}h ${minutes}m ago"
-        else
-            echo "${minutes}m ago"
-        fi
+        echo "@ago:$(stat -c %Y "$1" 2>/dev/null || stat -f %m "$1" 2>/dev/null)"
     else
         echo "N/A"
     fi
@@ -0,0 +1,39 @@
+#!/bin/bash
+set -euo pipefail
+
+# Prints a system status box definition as JSON for `boxed watch`:
+#   boxed watch --interval 5s -- ./examples/system-monitor-json.sh
+
+get_cpu_usage() {
+    top -bn1 | grep "Cpu(s)" | sed "s/.*, *\([0-9.]*\)%* id.*/\1/" | awk '{print 100 - $1"%"}'
+}
+
+get_memory_usage() {
+    free | grep Mem | awk '{printf "%.1f%%", $3/$2 * 100.0}'
+}
+
+get_disk_usage() {
+    df -h / | awk 'NR==2 {print $5}'
+}
+
+get_load_average() {
+    uptime | awk -F'load average:' '{print $2}' | awk '{print $1}' | tr -d ','
+}
+
+get_uptime() {
+    uptime -p | sed 's/up //'
+}
+
+cat <<JSON
+{
+  "title": "System Monitor",
+  "subtitle": "$(get_uptime)",
+  "kv": {
+    "CPU": "$(get_cpu_usage)",
+    "Memory": "$(get_memory_usage)",
+    "Disk": "$(get_disk_usage)",
+    "Load": "$(get_load_average)"
+  },
+  "rules": ["CPU>90:error", "CPU>75:warning", "Memory>90:error", "Memory>75:warning", "Disk>90:error", "Disk>75:warning"]
+}
+JSON
@@ -13,6 +13,10 @@ const (
 	Error   BoxType = "error"
 	Info    BoxType = "info"
 	Warning BoxType = "warning"
+	// Pending marks work still in progress in the live modes (spin, tail, watch).
+	// It is set internally and is not a type users can choose, so it is left out
+	// of IsValid and AllBoxTypes.
+	Pending BoxType = "pending"
 )
 
 func (b BoxType) String() string {
@@ -35,15 +39,58 @@ func AllBoxTypes() []BoxType {
 // KV represents key-value metadata displayed in the box content area.
 // The String() method exists primarily for testing and debugging; the actual
 // rendering logic uses Key and Value fields directly to allow flexible formatting.
+//
+// Series holds numeric samples for values written as "@spark:1,2,3". Renderers
+// draw it as a sparkline, while Value keeps the latest sample as plain text so
+// renderers without sparkline support still show something meaningful.
+//
+// Status optionally marks a single row as success/error/warning/info so a
+// mixed box can highlight the bad row. The zero value means "no status" and
+// the row is rendered in the default style.
 type KV struct {
-	Key   string
-	Value string
+	Key    string
+	Value  string
+	Series []float64
+	Status BoxType
 }
 
 func (kv KV) String() string {
 	return fmt.Sprintf("%s=%s", kv.Key, kv.Value)
 }
 
+// Key alignments for KVLayout.KeyAlign; the empty string means KeyAlignLeft.
+const (
+	KeyAlignLeft  = "left"
+	KeyAlignRight = "right"
+)
+
+// ColumnsAuto lets the renderer pick KVLayout.Columns from the available width.
+const ColumnsAuto = -1
+
+// SeparatorDots selects dotted leaders ("Nodes ....... 3/3") instead of a literal
+// separator.
+const SeparatorDots = "dots"
+
+// KVLayout controls how KV pairs are laid out. The zero value is the classic
+// layout: left-aligned keys, a plain gap, and a blank line between pairs.
+//
+// Separator is drawn between keys and values; ":" is attached to the key like in
+// prose, anything else gets a space on both sides. AlignNumbers right-aligns values
+// that start with a number so their digits and units line up. Compact drops the
+// blank line between pairs, which halves the height of long lists.
+//
+// Columns flows the pairs into that many side-by-side groups; 0 and 1 mean a
+// single column. MaxWidth caps the content width ColumnsAuto may fill, e.g. the
+// terminal's; 0 leaves it to the renderer's maximum.
+type KVLayout struct {
+	KeyAlign     string
+	Separator    string
+	AlignNumbers bool
+	Compact      bool
+	Columns      int
+	MaxWidth     int
+}
+
 // Box is the core data model representing all content and configuration for
 // a single terminal box render. It intentionally separates data (what to display)
 // from presentation (how to display it), enabling dependency injection of different
@@ -53,20 +100,40 @@ func (kv KV) String() string {
 // default to prevent text wrapping in status banners. BorderStyle maps to Lip Gloss
 // border presets but is stored as a string to avoid coupling this package to the
 // rendering library.
+//
+// Height is a minimum number of rendered lines, used when laying out several
+// boxes side by side so they line up. Like Width, 0 means "natural size".
+//
+// Body is free-form text shown after the KV pairs with its line breaks preserved,
+// for content that isn't key-value shaped such as log excerpts. Children are complete
+// boxes rendered inside the content area after that, each keeping its own type color
+// (e.g. one sub-box per service in a release).
+//
+// Layout holds the KV layout options; like BorderStyle it only affects presentation.
+//
+// Markup enables inline markup such as **bold**, {red}colors{/} and "[#123](https://...)"
+// links in the title, subtitle, values, body and footer. It's set for boxes written
+// by the user unless they ask for raw text, and left off for boxes built from
+// collected data, whose text is shown as-is.
 type Box struct {
 	Type     BoxType
 	Title    string
 	Subtitle string
 	KVPairs  []KV
+	Body     string
+	Children []*Box
 	Footer   string
 
 	Width       int
+	Height      int
 	BorderStyle string
+	Layout      KVLayout
+	Markup      bool
 }
 
 // HasContent determines if the box contains any displayable data beyond just
 // type information. Used by validators to fail-fast when users attempt to render
 // an effectively empty box, which likely indicates a CLI usage error.
 func (b *Box) HasContent() bool {
-	return b.Title != "" || b.Subtitle != "" || len(b.KVPairs) > 0 || b.Footer != ""
+	return b.Title != "" || b.Subtitle != "" || len(b.KVPairs) > 0 || b.Body != "" || len(b.Children) > 0 || b.Footer != ""
 }
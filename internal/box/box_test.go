@@ -16,6 +16,7 @@ func TestBoxType_IsValid(t *testing.T) {
 		{"error is valid", Error, true},
 		{"info is valid", Info, true},
 		{"warning is valid", Warning, true},
+		{"pending is internal only", Pending, false},
 		{"invalid type", BoxType("invalid"), false},
 		{"empty type", BoxType(""), false},
 	}
@@ -32,6 +33,7 @@ func TestBoxType_String(t *testing.T) {
 	assert.Equal(t, "error", Error.String())
 	assert.Equal(t, "info", Info.String())
 	assert.Equal(t, "warning", Warning.String())
+	assert.Equal(t, "pending", Pending.String())
 }
 
 func TestAllBoxTypes(t *testing.T) {
@@ -41,6 +43,7 @@ func TestAllBoxTypes(t *testing.T) {
 	assert.Contains(t, types, Error)
 	assert.Contains(t, types, Info)
 	assert.Contains(t, types, Warning)
+	assert.NotContains(t, types, Pending, "pending is not a public subcommand")
 }
 
 func TestKV_String(t *testing.T) {
@@ -74,6 +77,16 @@ func TestBox_HasContent(t *testing.T) {
 			box:      &Box{KVPairs: []KV{{Key: "k", Value: "v"}}},
 			expected: true,
 		},
+		{
+			name:     "box with body has content",
+			box:      &Box{Body: "log line"},
+			expected: true,
+		},
+		{
+			name:     "box with children has content",
+			box:      &Box{Children: []*Box{{Title: "child"}}},
+			expected: true,
+		},
 		{
 			name:     "box with footer has content",
 			box:      &Box{Footer: "Footer"},
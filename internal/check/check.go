@@ -0,0 +1,50 @@
+// Package check runs health checks (HTTP endpoints, TLS certificates) and turns the
+// results into boxes. Checks return plain result structs so callers and tests can
+// inspect them; building the box is a separate step.
+package check
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"boxed/internal/box"
+)
+
+// Default certificate expiry thresholds in days, matching common renewal practice:
+// warn a month ahead, fail when there is only a week left.
+const (
+	DefaultWarnDays  = 30
+	DefaultErrorDays = 7
+)
+
+// TimeLayout is used for "checked at" footers and certificate dates.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// DaysUntil returns whole days from now until t, negative once t has passed.
+func DaysUntil(t, now time.Time) int {
+	return int(math.Floor(t.Sub(now).Hours() / 24))
+}
+
+// ExpiryStatus grades the days left on a certificate. A threshold of zero or less
+// disables that level; an expired certificate is always an error.
+func ExpiryStatus(days, warnDays, errorDays int) box.BoxType {
+	switch {
+	case days < 0 || (errorDays > 0 && days < errorDays):
+		return box.Error
+	case warnDays > 0 && days < warnDays:
+		return box.Warning
+	}
+	return box.Success
+}
+
+// formatDays describes the days left on a certificate.
+func formatDays(days int) string {
+	switch {
+	case days < 0:
+		return fmt.Sprintf("expired %dd ago", -days)
+	case days == 1:
+		return "1 day left"
+	}
+	return fmt.Sprintf("%d days left", days)
+}
@@ -0,0 +1,55 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaysUntil(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want int
+	}{
+		{name: "future", t: now.Add(45 * 24 * time.Hour), want: 45},
+		{name: "partial day rounds down", t: now.Add(36 * time.Hour), want: 1},
+		{name: "later today", t: now.Add(time.Hour), want: 0},
+		{name: "expired", t: now.Add(-time.Hour), want: -1},
+		{name: "expired days ago", t: now.Add(-72 * time.Hour), want: -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DaysUntil(tt.t, now))
+		})
+	}
+}
+
+func TestExpiryStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		days      int
+		warnDays  int
+		errorDays int
+		want      box.BoxType
+	}{
+		{name: "plenty left", days: 90, warnDays: 30, errorDays: 7, want: box.Success},
+		{name: "at warn threshold", days: 30, warnDays: 30, errorDays: 7, want: box.Success},
+		{name: "below warn threshold", days: 29, warnDays: 30, errorDays: 7, want: box.Warning},
+		{name: "below error threshold", days: 6, warnDays: 30, errorDays: 7, want: box.Error},
+		{name: "expired", days: -1, warnDays: 0, errorDays: 0, want: box.Error},
+		{name: "thresholds disabled", days: 1, warnDays: 0, errorDays: 0, want: box.Success},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExpiryStatus(tt.days, tt.warnDays, tt.errorDays))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"boxed/internal/box"
+	"boxed/internal/rules"
+)
+
+// maxBodyBytes bounds how much of a response is searched for --contains, so a
+// misbehaving endpoint streaming forever can't hold the check past its timeout.
+const maxBodyBytes = 1024 * 1024
+
+// HTTPOptions configures CheckHTTP.
+type HTTPOptions struct {
+	// Timeout applies to each request, including reading the body.
+	Timeout time.Duration
+	// ExpectStatus lists acceptable status codes; empty accepts any 2xx.
+	ExpectStatus []int
+	// Contains, when set, must appear in the response body.
+	Contains string
+	// SlowAfter marks responses slower than this as warnings; zero disables it.
+	SlowAfter time.Duration
+	// WarnDays and ErrorDays grade the certificate expiry of HTTPS endpoints.
+	WarnDays  int
+	ErrorDays int
+	// Client is used for requests; nil uses a client with default settings. Tests
+	// pass the httptest server's client so its certificate is trusted.
+	Client *http.Client
+}
+
+// HTTPResult is the outcome of checking one URL.
+type HTTPResult struct {
+	URL        string
+	StatusCode int
+	Latency    time.Duration
+	// CertExpiry is the leaf certificate's NotAfter; zero for plain HTTP.
+	CertExpiry time.Time
+	// ContentFound reports whether HTTPOptions.Contains was found in the body.
+	ContentFound bool
+	Err          error
+	Status       box.BoxType
+	// Problems explains a non-success Status, one short phrase per failed check.
+	Problems []string
+}
+
+// CheckHTTP requests every URL concurrently and returns results in the order of urls.
+func CheckHTTP(ctx context.Context, urls []string, opts HTTPOptions, now time.Time) []HTTPResult {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	results := make([]HTTPResult, len(urls))
+	var wg sync.WaitGroup
+	for i, rawURL := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = checkURL(ctx, client, rawURL, opts, now)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func checkURL(ctx context.Context, client *http.Client, rawURL string, opts HTTPOptions, now time.Time) HTTPResult {
+	result := HTTPResult{URL: rawURL, Status: box.Success}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return result.fail(fmt.Errorf("invalid URL: %w", err))
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Latency = time.Since(start)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return result.fail(fmt.Errorf("timeout after %s", opts.Timeout))
+		}
+		// The URL is already the row's key; repeating it in the value wastes the space
+		// the actual cause needs.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return result.fail(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	result.Latency = time.Since(start)
+	result.StatusCode = resp.StatusCode
+	if err != nil {
+		return result.fail(fmt.Errorf("reading body: %w", err))
+	}
+
+	if !statusAccepted(resp.StatusCode, opts.ExpectStatus) {
+		result.problem(box.Error, fmt.Sprintf("unexpected status %d", resp.StatusCode))
+	}
+	if opts.Contains != "" {
+		result.ContentFound = strings.Contains(string(body), opts.Contains)
+		if !result.ContentFound {
+			result.problem(box.Error, fmt.Sprintf("body missing %q", opts.Contains))
+		}
+	}
+	if opts.SlowAfter > 0 && result.Latency > opts.SlowAfter {
+		result.problem(box.Warning, fmt.Sprintf("slower than %s", opts.SlowAfter))
+	}
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		result.CertExpiry = resp.TLS.PeerCertificates[0].NotAfter
+		days := DaysUntil(result.CertExpiry, now)
+		if status := ExpiryStatus(days, opts.WarnDays, opts.ErrorDays); status != box.Success {
+			result.problem(status, "certificate "+formatDays(days))
+		}
+	}
+
+	return result
+}
+
+func (r HTTPResult) fail(err error) HTTPResult {
+	r.Err = err
+	r.Status = box.Error
+	return r
+}
+
+func (r *HTTPResult) problem(status box.BoxType, reason string) {
+	r.Status = rules.Worst(r.Status, status)
+	r.Problems = append(r.Problems, reason)
+}
+
+func statusAccepted(code int, expected []int) bool {
+	if len(expected) == 0 {
+		return code >= 200 && code < 300
+	}
+	return slices.Contains(expected, code)
+}
+
+// HTTPBox summarizes results in one box with a row per endpoint. The box type is the
+// worst endpoint status, so a single failing endpoint makes the whole check fail.
+// Rows are keyed by the full URL: checking both http:// and https:// for the same
+// host is common, and the two rows need to stay apart.
+func HTTPBox(results []HTTPResult, opts HTTPOptions, now time.Time) *box.Box {
+	b := &box.Box{
+		Type:   box.Success,
+		Title:  "HTTP Check",
+		Footer: "Checked at " + now.Format(TimeLayout),
+	}
+
+	healthy := 0
+	for _, result := range results {
+		b.Type = rules.Worst(b.Type, result.Status)
+		if result.Status == box.Success {
+			healthy++
+		}
+		b.KVPairs = append(b.KVPairs, box.KV{
+			Key:    result.URL,
+			Value:  result.describe(opts, now),
+			Status: result.Status,
+		})
+	}
+	b.Subtitle = fmt.Sprintf("%d/%d healthy", healthy, len(results))
+
+	return b
+}
+
+// describe lists the measurements for one endpoint, followed by whatever failed.
+func (r HTTPResult) describe(opts HTTPOptions, now time.Time) string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+
+	parts := []string{
+		fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		formatLatency(r.Latency),
+	}
+	if !r.CertExpiry.IsZero() {
+		parts = append(parts, fmt.Sprintf("TLS %dd", DaysUntil(r.CertExpiry, now)))
+	}
+	if opts.Contains != "" && r.ContentFound {
+		parts = append(parts, "content ok")
+	}
+	parts = append(parts, r.Problems...)
+	return strings.Join(parts, " • ")
+}
+
+// formatLatency rounds to milliseconds, which is as precise as network timings get.
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1ms"
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,172 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"ok"}`)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/created", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/hang", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCheckHTTP(t *testing.T) {
+	server := newTestServer(t)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		path        string
+		opts        HTTPOptions
+		wantStatus  box.BoxType
+		wantCode    int
+		wantProblem string
+		wantErr     string
+	}{
+		{name: "healthy", path: "/health", wantStatus: box.Success, wantCode: 200},
+		{name: "server error", path: "/broken", wantStatus: box.Error, wantCode: 500, wantProblem: "unexpected status 500"},
+		{name: "not found", path: "/missing", wantStatus: box.Error, wantCode: 404, wantProblem: "unexpected status 404"},
+		{name: "any 2xx accepted", path: "/created", wantStatus: box.Success, wantCode: 201},
+		{name: "expected status", path: "/broken", opts: HTTPOptions{ExpectStatus: []int{500}}, wantStatus: box.Success, wantCode: 500},
+		{name: "unexpected 2xx", path: "/created", opts: HTTPOptions{ExpectStatus: []int{200}}, wantStatus: box.Error, wantCode: 201, wantProblem: "unexpected status 201"},
+		{name: "content found", path: "/health", opts: HTTPOptions{Contains: `"ok"`}, wantStatus: box.Success, wantCode: 200},
+		{name: "content missing", path: "/health", opts: HTTPOptions{Contains: "ready"}, wantStatus: box.Error, wantCode: 200, wantProblem: `body missing "ready"`},
+		{name: "slow", path: "/health", opts: HTTPOptions{SlowAfter: time.Nanosecond}, wantStatus: box.Warning, wantCode: 200, wantProblem: "slower than 1ns"},
+		{name: "timeout", path: "/hang", opts: HTTPOptions{Timeout: 50 * time.Millisecond}, wantStatus: box.Error, wantErr: "timeout after 50ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := CheckHTTP(context.Background(), []string{server.URL + tt.path}, tt.opts, now)
+			require.Len(t, results, 1)
+			result := results[0]
+
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantCode, result.StatusCode)
+			if tt.wantProblem != "" {
+				assert.Contains(t, result.Problems, tt.wantProblem)
+			} else {
+				assert.Empty(t, result.Problems)
+			}
+			if tt.wantErr != "" {
+				require.Error(t, result.Err)
+				assert.Equal(t, tt.wantErr, result.Err.Error())
+			} else {
+				assert.NoError(t, result.Err)
+			}
+		})
+	}
+}
+
+func TestCheckHTTPConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	results := CheckHTTP(context.Background(), []string{url}, HTTPOptions{}, time.Now())
+	require.Len(t, results, 1)
+	assert.Equal(t, box.Error, results[0].Status)
+	require.Error(t, results[0].Err)
+	assert.NotContains(t, results[0].Err.Error(), url, "the URL is already the row key")
+}
+
+func TestCheckHTTPTLSExpiry(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	now := time.Now()
+	expiry := server.Certificate().NotAfter
+	days := DaysUntil(expiry, now)
+
+	tests := []struct {
+		name       string
+		warnDays   int
+		errorDays  int
+		wantStatus box.BoxType
+	}{
+		{name: "far from expiry", warnDays: DefaultWarnDays, errorDays: DefaultErrorDays, wantStatus: box.Success},
+		{name: "within warn days", warnDays: days + 1, errorDays: DefaultErrorDays, wantStatus: box.Warning},
+		{name: "within error days", warnDays: days + 2, errorDays: days + 1, wantStatus: box.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := HTTPOptions{WarnDays: tt.warnDays, ErrorDays: tt.errorDays, Client: server.Client()}
+			results := CheckHTTP(context.Background(), []string{server.URL}, opts, now)
+			require.Len(t, results, 1)
+
+			assert.NoError(t, results[0].Err)
+			assert.Equal(t, expiry, results[0].CertExpiry)
+			assert.Equal(t, tt.wantStatus, results[0].Status)
+		})
+	}
+}
+
+func TestCheckHTTPIsConcurrent(t *testing.T) {
+	server := newTestServer(t)
+	urls := []string{server.URL + "/hang", server.URL + "/hang", server.URL + "/health"}
+
+	start := time.Now()
+	results := CheckHTTP(context.Background(), urls, HTTPOptions{Timeout: 200 * time.Millisecond}, time.Now())
+	elapsed := time.Since(start)
+
+	require.Len(t, results, 3)
+	assert.Less(t, elapsed, 400*time.Millisecond, "requests should time out in parallel")
+	assert.Equal(t, server.URL+"/health", results[2].URL, "results keep the order of the URLs")
+	assert.Equal(t, box.Success, results[2].Status)
+}
+
+func TestHTTPBox(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+	opts := HTTPOptions{Contains: "ok"}
+	results := []HTTPResult{
+		{URL: "https://api.example.com/health", StatusCode: 200, Latency: 123 * time.Millisecond, CertExpiry: now.Add(45 * 24 * time.Hour), ContentFound: true, Status: box.Success},
+		{URL: "http://slow.example.com", StatusCode: 200, Latency: 2 * time.Second, ContentFound: true, Status: box.Warning, Problems: []string{"slower than 1s"}},
+		{URL: "http://down.example.com", Err: fmt.Errorf("connection refused"), Status: box.Error},
+		{URL: "https://down.example.com", StatusCode: 200, Latency: 80 * time.Millisecond, ContentFound: true, Status: box.Success},
+	}
+
+	b := HTTPBox(results, opts, now)
+
+	assert.Equal(t, box.Error, b.Type, "the worst endpoint decides the type")
+	assert.Equal(t, "HTTP Check", b.Title)
+	assert.Equal(t, "2/4 healthy", b.Subtitle)
+	assert.Equal(t, "Checked at 2025-10-19 12:00:00", b.Footer)
+	assert.Equal(t, []box.KV{
+		{Key: "https://api.example.com/health", Value: "200 OK • 123ms • TLS 45d • content ok", Status: box.Success},
+		{Key: "http://slow.example.com", Value: "200 OK • 2s • content ok • slower than 1s", Status: box.Warning},
+		{Key: "http://down.example.com", Value: "connection refused", Status: box.Error},
+		{Key: "https://down.example.com", Value: "200 OK • 80ms • content ok", Status: box.Success},
+	}, b.KVPairs)
+
+	b = HTTPBox(results[:2], opts, now)
+	assert.Equal(t, box.Warning, b.Type)
+	assert.True(t, strings.HasPrefix(b.Subtitle, "1/2"))
+}
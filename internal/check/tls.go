@@ -0,0 +1,185 @@
+package check
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"boxed/internal/box"
+	"boxed/internal/rules"
+)
+
+// TLSOptions configures InspectTLS and InspectPEM.
+type TLSOptions struct {
+	// Timeout bounds connecting and the handshake.
+	Timeout time.Duration
+	// ServerName is the name the certificate must be valid for. InspectTLS defaults it
+	// to the host being dialled; for PEM files the name isn't checked unless set.
+	ServerName string
+	// Roots verifies the chain; nil uses the system roots.
+	Roots *x509.CertPool
+	// WarnDays and ErrorDays grade the leaf certificate's expiry.
+	WarnDays  int
+	ErrorDays int
+}
+
+// CertReport describes a certificate chain and whether it verified.
+type CertReport struct {
+	// Source is the address or file the chain came from.
+	Source string
+	// Chain holds the certificates as presented, leaf first.
+	Chain []*x509.Certificate
+	// VerifyErr is why the chain didn't verify; nil when it did.
+	VerifyErr error
+}
+
+// InspectTLS connects to addr (host:port, port defaulting to 443) and reports the
+// presented chain. The handshake itself skips verification so an invalid chain can
+// still be shown; it is verified afterwards against opts.Roots.
+func InspectTLS(ctx context.Context, addr string, opts TLSOptions, now time.Time) (CertReport, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		host, port = addr, "443"
+	}
+	if opts.ServerName == "" {
+		opts.ServerName = host
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	dialer := &tls.Dialer{Config: &tls.Config{
+		ServerName: opts.ServerName,
+		// Verification happens in verifyChain so expired or untrusted certificates
+		// are reported rather than aborting the connection.
+		InsecureSkipVerify: true,
+	}}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return CertReport{}, fmt.Errorf("connecting to %s: timeout after %s", addr, opts.Timeout)
+		}
+		return CertReport{}, fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	chain := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(chain) == 0 {
+		return CertReport{}, fmt.Errorf("%s presented no certificates", addr)
+	}
+	report := CertReport{Source: addr, Chain: chain}
+	report.VerifyErr = verifyChain(chain, opts, now)
+	return report, nil
+}
+
+// InspectPEM reports on a PEM bundle: the first certificate is the leaf and the rest
+// are used as intermediates, the same order a server would present them in.
+func InspectPEM(data []byte, source string, opts TLSOptions, now time.Time) (CertReport, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return CertReport{}, fmt.Errorf("parsing certificate %d in %s: %w", len(chain)+1, source, err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return CertReport{}, fmt.Errorf("no PEM certificates found in %s", source)
+	}
+
+	report := CertReport{Source: source, Chain: chain}
+	report.VerifyErr = verifyChain(chain, opts, now)
+	return report, nil
+}
+
+// ReadCertPool loads PEM certificates to use as trusted roots.
+func ReadCertPool(data []byte, source string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", source)
+	}
+	return pool, nil
+}
+
+func verifyChain(chain []*x509.Certificate, opts TLSOptions, now time.Time) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		DNSName:       opts.ServerName,
+		Roots:         opts.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+	return err
+}
+
+// TLSBox shows the leaf certificate's details. Chain validity and expiry carry their
+// own row statuses; the box takes the worst of them.
+func TLSBox(report CertReport, opts TLSOptions, now time.Time) *box.Box {
+	leaf := report.Chain[0]
+	days := DaysUntil(leaf.NotAfter, now)
+	expiryStatus := ExpiryStatus(days, opts.WarnDays, opts.ErrorDays)
+
+	chain := box.KV{Key: "Chain", Value: fmt.Sprintf("valid (%s)", pluralize(len(report.Chain), "certificate")), Status: box.Success}
+	if report.VerifyErr != nil {
+		chain = box.KV{Key: "Chain", Value: report.VerifyErr.Error(), Status: box.Error}
+	}
+
+	sans := "none"
+	if names := subjectAltNames(leaf); len(names) > 0 {
+		sans = strings.Join(names, ", ")
+	}
+
+	b := &box.Box{
+		Title:    "TLS Certificate",
+		Subtitle: report.Source,
+		KVPairs: []box.KV{
+			{Key: "Subject", Value: leaf.Subject.String()},
+			{Key: "SANs", Value: sans},
+			{Key: "Issuer", Value: leaf.Issuer.String()},
+			chain,
+			{Key: "Expires", Value: leaf.NotAfter.UTC().Format(TimeLayout) + " (" + formatDays(days) + ")", Status: expiryStatus},
+		},
+		Footer: "Checked at " + now.Format(TimeLayout),
+	}
+	b.Type = rules.Worst(chain.Status, expiryStatus)
+	return b
+}
+
+func subjectAltNames(cert *x509.Certificate) []string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+	return names
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,166 @@
+package check
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func (c testCert) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der})
+}
+
+// newTestCert issues a certificate signed by parent, or a self-signed CA when parent is nil.
+func newTestCert(t *testing.T, parent *testCert, commonName string, notAfter time.Time) testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Boxed Test"}},
+		NotBefore:    time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		template.DNSNames = []string{commonName, "www." + commonName}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCert{cert: cert, key: key, der: der}
+}
+
+func rootPool(ca testCert) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+func TestInspectPEM(t *testing.T) {
+	now := time.Date(2025, 10, 19, 12, 0, 0, 0, time.UTC)
+	ca := newTestCert(t, nil, "Test Root", now.Add(10*365*24*time.Hour))
+	otherCA := newTestCert(t, nil, "Other Root", now.Add(10*365*24*time.Hour))
+
+	tests := []struct {
+		name       string
+		leafExpiry time.Time
+		roots      testCert
+		serverName string
+		wantType   box.BoxType
+		wantChain  string
+		wantExpiry string
+	}{
+		{name: "valid", leafExpiry: now.Add(90 * 24 * time.Hour), roots: ca, wantType: box.Success, wantChain: "valid (1 certificate)", wantExpiry: "2026-01-17 12:00:00 (90 days left)"},
+		{name: "expiring soon", leafExpiry: now.Add(20 * 24 * time.Hour), roots: ca, wantType: box.Warning, wantChain: "valid (1 certificate)", wantExpiry: "2025-11-08 12:00:00 (20 days left)"},
+		{name: "about to expire", leafExpiry: now.Add(3 * 24 * time.Hour), roots: ca, wantType: box.Error, wantChain: "valid (1 certificate)", wantExpiry: "2025-10-22 12:00:00 (3 days left)"},
+		{name: "expired", leafExpiry: now.Add(-48 * time.Hour), roots: ca, wantType: box.Error, wantChain: "x509: certificate has expired or is not yet valid", wantExpiry: "2025-10-17 12:00:00 (expired 2d ago)"},
+		{name: "untrusted", leafExpiry: now.Add(90 * 24 * time.Hour), roots: otherCA, wantType: box.Error, wantChain: "x509: certificate signed by unknown authority", wantExpiry: "2026-01-17 12:00:00 (90 days left)"},
+		{name: "matching name", leafExpiry: now.Add(90 * 24 * time.Hour), roots: ca, serverName: "www.example.com", wantType: box.Success, wantChain: "valid (1 certificate)", wantExpiry: "2026-01-17 12:00:00 (90 days left)"},
+		{name: "wrong name", leafExpiry: now.Add(90 * 24 * time.Hour), roots: ca, serverName: "other.org", wantType: box.Error, wantChain: "x509: certificate is valid for example.com, www.example.com, not other.org", wantExpiry: "2026-01-17 12:00:00 (90 days left)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaf := newTestCert(t, &ca, "example.com", tt.leafExpiry)
+			opts := TLSOptions{Roots: rootPool(tt.roots), ServerName: tt.serverName, WarnDays: DefaultWarnDays, ErrorDays: DefaultErrorDays}
+
+			report, err := InspectPEM(leaf.pem(), "cert.pem", opts, now)
+			require.NoError(t, err)
+			b := TLSBox(report, opts, now)
+
+			assert.Equal(t, tt.wantType, b.Type)
+			assert.Equal(t, "cert.pem", b.Subtitle)
+			assert.Equal(t, box.KV{Key: "Subject", Value: "CN=example.com,O=Boxed Test"}, b.KVPairs[0])
+			assert.Equal(t, box.KV{Key: "SANs", Value: "example.com, www.example.com, 127.0.0.1"}, b.KVPairs[1])
+			assert.Equal(t, box.KV{Key: "Issuer", Value: "CN=Test Root,O=Boxed Test"}, b.KVPairs[2])
+			assert.Contains(t, b.KVPairs[3].Value, tt.wantChain)
+			assert.Equal(t, tt.wantExpiry, b.KVPairs[4].Value)
+		})
+	}
+}
+
+func TestInspectPEMChain(t *testing.T) {
+	now := time.Now()
+	root := newTestCert(t, nil, "Test Root", now.Add(10*365*24*time.Hour))
+	intermediate := newTestCert(t, &root, "Test Intermediate", now.Add(5*365*24*time.Hour))
+	intermediate.cert.IsCA = true
+	leaf := newTestCert(t, &intermediate, "example.com", now.Add(90*24*time.Hour))
+
+	bundle := append(leaf.pem(), intermediate.pem()...)
+	report, err := InspectPEM(bundle, "bundle.pem", TLSOptions{Roots: rootPool(root)}, now)
+	require.NoError(t, err)
+
+	assert.Len(t, report.Chain, 2)
+	assert.Equal(t, "example.com", report.Chain[0].Subject.CommonName)
+	assert.Error(t, report.VerifyErr, "the intermediate was not issued as a CA certificate")
+
+	_, err = InspectPEM([]byte("not a certificate"), "junk.pem", TLSOptions{}, now)
+	assert.EqualError(t, err, "no PEM certificates found in junk.pem")
+}
+
+func TestInspectTLS(t *testing.T) {
+	now := time.Now()
+	ca := newTestCert(t, nil, "Test Root", now.Add(10*365*24*time.Hour))
+	leaf := newTestCert(t, &ca, "example.com", now.Add(20*24*time.Hour))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{leaf.der}, PrivateKey: leaf.key}}}
+	server.StartTLS()
+	defer server.Close()
+	addr := server.Listener.Addr().String()
+
+	opts := TLSOptions{Roots: rootPool(ca), Timeout: time.Second, WarnDays: DefaultWarnDays, ErrorDays: DefaultErrorDays}
+	report, err := InspectTLS(context.Background(), addr, opts, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, addr, report.Source)
+	require.Len(t, report.Chain, 1)
+	assert.Equal(t, leaf.cert.SerialNumber, report.Chain[0].SerialNumber)
+	assert.NoError(t, report.VerifyErr, "the leaf is valid for 127.0.0.1")
+	assert.Equal(t, box.Warning, TLSBox(report, opts, now).Type)
+
+	opts.ServerName = "other.org"
+	report, err = InspectTLS(context.Background(), addr, opts, now)
+	require.NoError(t, err)
+	assert.Error(t, report.VerifyErr)
+
+	server.Close()
+	_, err = InspectTLS(context.Background(), addr, opts, now)
+	assert.ErrorContains(t, err, "connecting to "+addr)
+}
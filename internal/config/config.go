@@ -0,0 +1,309 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"boxed/internal/box"
+	"boxed/internal/parser"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is looked up in the working directory and its parents, so a
+// repository can share settings for every script in it.
+const ProjectFileName = ".boxed.yaml"
+
+// Settings is one layer of box options: a file's defaults or one of its presets.
+// Empty fields leave the value of lower layers in place; the switches are pointers
+// so "compact: false" can still turn off what a lower layer turned on.
+type Settings struct {
+	Title        string   `yaml:"title"`
+	Subtitle     string   `yaml:"subtitle"`
+	Body         string   `yaml:"body"`
+	Footer       string   `yaml:"footer"`
+	Width        int      `yaml:"width"`
+	BorderStyle  string   `yaml:"border-style"`
+	Locale       string   `yaml:"locale"`
+	KeyAlign     string   `yaml:"key-align"`
+	Separator    string   `yaml:"separator"`
+	AlignNumbers *bool    `yaml:"align-numbers"`
+	Compact      *bool    `yaml:"compact"`
+	Columns      string   `yaml:"columns"`
+	Raw          *bool    `yaml:"raw"`
+	KV           []string `yaml:"kv"`
+	Rules        []string `yaml:"rules"`
+}
+
+// File is the content of a config file.
+type File struct {
+	Defaults Settings            `yaml:"defaults"`
+	Presets  map[string]Settings `yaml:"presets"`
+}
+
+// Config holds the user and project config files; either may be missing.
+type Config struct {
+	User        *File
+	UserPath    string
+	Project     *File
+	ProjectPath string
+}
+
+// UserDir returns $XDG_CONFIG_HOME/boxed, falling back to ~/.config as the XDG spec
+// says, or "" when neither is set.
+func UserDir(getenv func(string) string) string {
+	dir := getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home := getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "boxed")
+}
+
+// UserPath returns the user config file: $BOXED_CONFIG if set, otherwise
+// config.yaml in UserDir. explicit reports whether the path came from BOXED_CONFIG,
+// in which case a missing file is an error rather than "no config".
+func UserPath(getenv func(string) string) (path string, explicit bool) {
+	if path := getenv("BOXED_CONFIG"); path != "" {
+		return path, true
+	}
+	dir := UserDir(getenv)
+	if dir == "" {
+		return "", false
+	}
+	return filepath.Join(dir, "config.yaml"), false
+}
+
+// ProjectPath returns the nearest .boxed.yaml in dir or its parents, or "" if
+// there is none.
+func ProjectPath(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load reads the user config and the project config found from dir.
+func Load(dir string) (*Config, error) {
+	userPath, explicit := UserPath(os.Getenv)
+	return LoadFiles(userPath, explicit, ProjectPath(dir))
+}
+
+// LoadFiles reads config files from explicit paths; empty paths are skipped. A
+// missing user file is only an error when required is set.
+func LoadFiles(userPath string, required bool, projectPath string) (*Config, error) {
+	c := &Config{}
+	if userPath != "" {
+		f, err := readFile(userPath)
+		switch {
+		case err == nil:
+			c.User, c.UserPath = f, userPath
+		case !errors.Is(err, fs.ErrNotExist) || required:
+			return nil, err
+		}
+	}
+	if projectPath != "" {
+		f, err := readFile(projectPath)
+		if err != nil {
+			return nil, err
+		}
+		c.Project, c.ProjectPath = f, projectPath
+	}
+	return c, nil
+}
+
+// readFile rejects unknown keys so a typo like "border_style" is reported instead of
+// silently ignored.
+func readFile(path string) (*File, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	var f File
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Resolve merges the layers below the command line, lowest first: user defaults,
+// project defaults, then the named preset (if any). A project preset replaces a user
+// preset of the same name. Scalars from higher layers win; KV pairs and rules
+// accumulate so defaults can add rows that presets extend.
+func (c *Config) Resolve(preset string) (parser.Options, error) {
+	var layers []Settings
+	presets := map[string]Settings{}
+	for _, f := range []*File{c.User, c.Project} {
+		if f == nil {
+			continue
+		}
+		layers = append(layers, f.Defaults)
+		for name, settings := range f.Presets {
+			presets[name] = settings
+		}
+	}
+
+	if preset != "" {
+		settings, ok := presets[preset]
+		if !ok {
+			return parser.Options{}, c.unknownPreset(preset, presets)
+		}
+		layers = append(layers, settings)
+	}
+
+	var opts parser.Options
+	for _, layer := range layers {
+		opts = Merge(layer.Options(), opts)
+	}
+	return opts, nil
+}
+
+func (c *Config) unknownPreset(name string, presets map[string]Settings) error {
+	if len(presets) == 0 {
+		return fmt.Errorf("unknown preset %q: no presets defined in %s", name, c.describeSources())
+	}
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return fmt.Errorf("unknown preset %q, must be one of: %s", name, strings.Join(names, ", "))
+}
+
+func (c *Config) describeSources() string {
+	var paths []string
+	for _, path := range []string{c.UserPath, c.ProjectPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if len(paths) == 0 {
+		return "any config file (none found)"
+	}
+	return strings.Join(paths, " or ")
+}
+
+// Options converts the layer to box options. Unset fields stay empty so Merge can
+// fill them from lower layers.
+func (s Settings) Options() parser.Options {
+	return parser.Options{
+		Title:       s.Title,
+		Subtitle:    s.Subtitle,
+		Body:        s.Body,
+		Footer:      s.Footer,
+		Width:       s.Width,
+		BorderStyle: s.BorderStyle,
+		Locale:      s.Locale,
+		KVFlags:     slices.Clone(s.KV),
+		Rules:       slices.Clone(s.Rules),
+		Layout: box.KVLayout{
+			KeyAlign:  s.KeyAlign,
+			Separator: s.Separator,
+		},
+		AlignNumbers: s.AlignNumbers,
+		Compact:      s.Compact,
+		Columns:      s.Columns,
+		Raw:          s.Raw,
+	}
+}
+
+// Merge fills the empty scalar fields of opts from fallback. Fallback KV pairs come
+// first so defaults keep their position above rows added later, and a key opts sets
+// again replaces the fallback's row in place instead of adding a second one. Rules
+// and children are combined.
+func Merge(opts, fallback parser.Options) parser.Options {
+	if opts.Title == "" {
+		opts.Title = fallback.Title
+	}
+	if opts.Subtitle == "" {
+		opts.Subtitle = fallback.Subtitle
+	}
+	if opts.Body == "" {
+		opts.Body = fallback.Body
+	}
+	if opts.Footer == "" {
+		opts.Footer = fallback.Footer
+	}
+	if opts.Width == 0 {
+		opts.Width = fallback.Width
+	}
+	if opts.BorderStyle == "" {
+		opts.BorderStyle = fallback.BorderStyle
+	}
+	if opts.Locale == "" {
+		opts.Locale = fallback.Locale
+	}
+	if opts.Layout.KeyAlign == "" {
+		opts.Layout.KeyAlign = fallback.Layout.KeyAlign
+	}
+	if opts.Layout.Separator == "" {
+		opts.Layout.Separator = fallback.Layout.Separator
+	}
+	if opts.AlignNumbers == nil {
+		opts.AlignNumbers = fallback.AlignNumbers
+	}
+	if opts.Compact == nil {
+		opts.Compact = fallback.Compact
+	}
+	if opts.Raw == nil {
+		opts.Raw = fallback.Raw
+	}
+	if opts.Columns == "" {
+		opts.Columns = fallback.Columns
+	}
+	opts.KVFlags, opts.KVPairs = mergeKVs(opts, fallback)
+	opts.Rules = append(slices.Clone(fallback.Rules), opts.Rules...)
+	opts.Children = append(slices.Clone(fallback.Children), opts.Children...)
+	return opts
+}
+
+// mergeKVs combines the rows of both layers, fallback first. Flags are split into
+// pairs the way ParseBox would, so a key can replace one set in either form: the
+// value of opts takes the fallback's position. If either layer has a flag that
+// doesn't parse, the rows are kept as given for ParseBox to report.
+func mergeKVs(opts, fallback parser.Options) ([]string, []box.KV) {
+	lower, lowerErr := parser.SplitKVFlags(fallback.KVFlags)
+	higher, higherErr := parser.SplitKVFlags(opts.KVFlags)
+	if lowerErr != nil || higherErr != nil {
+		return append(slices.Clone(fallback.KVFlags), opts.KVFlags...), append(slices.Clone(fallback.KVPairs), opts.KVPairs...)
+	}
+
+	merged := append(lower, fallback.KVPairs...)
+	positions := map[string]int{}
+	for i, kv := range merged {
+		if _, ok := positions[kv.Key]; !ok {
+			positions[kv.Key] = i
+		}
+	}
+	for _, kv := range append(higher, opts.KVPairs...) {
+		// Each fallback row is replaced once; a key repeated in opts adds rows as
+		// it would on its own.
+		if i, ok := positions[kv.Key]; ok {
+			merged[i] = kv
+			delete(positions, kv.Key)
+			continue
+		}
+		merged = append(merged, kv)
+	}
+	return nil, merged
+}
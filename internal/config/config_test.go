@@ -0,0 +1,327 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"boxed/internal/box"
+	"boxed/internal/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userConfig = `
+defaults:
+  border-style: thick
+  footer: user footer
+  kv:
+    - Host=build-01
+presets:
+  deploy:
+    title: Deploy
+    width: 60
+    kv: ["Env=prod", "Host=deploy-01"]
+    rules: ["Errors>0:error"]
+    columns: 2
+    compact: true
+  release:
+    title: Release
+`
+
+const projectConfig = `
+defaults:
+  footer: project footer
+  width: 80
+presets:
+  release:
+    subtitle: from project
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	userPath := writeFile(t, dir, "user.yaml", userConfig)
+	projectPath := writeFile(t, dir, ".boxed.yaml", projectConfig)
+
+	tests := []struct {
+		name        string
+		userPath    string
+		projectPath string
+		preset      string
+		want        parser.Options
+		wantErr     string
+	}{
+		{
+			name:     "user defaults",
+			userPath: userPath,
+			want:     parser.Options{BorderStyle: "thick", Footer: "user footer", KVPairs: []box.KV{{Key: "Host", Value: "build-01"}}},
+		},
+		{
+			name:        "project overrides user",
+			userPath:    userPath,
+			projectPath: projectPath,
+			want:        parser.Options{BorderStyle: "thick", Footer: "project footer", Width: 80, KVPairs: []box.KV{{Key: "Host", Value: "build-01"}}},
+		},
+		{
+			name:        "preset overrides project and adds rows",
+			userPath:    userPath,
+			projectPath: projectPath,
+			preset:      "deploy",
+			want: parser.Options{
+				Title:       "Deploy",
+				BorderStyle: "thick",
+				Footer:      "project footer",
+				Width:       60,
+				KVPairs:     []box.KV{{Key: "Host", Value: "deploy-01"}, {Key: "Env", Value: "prod"}},
+				Rules:       []string{"Errors>0:error"},
+				Columns:     "2",
+				Compact:     boolPtr(true),
+			},
+		},
+		{
+			name:        "project preset replaces user preset",
+			userPath:    userPath,
+			projectPath: projectPath,
+			preset:      "release",
+			want:        parser.Options{Subtitle: "from project", BorderStyle: "thick", Footer: "project footer", Width: 80, KVPairs: []box.KV{{Key: "Host", Value: "build-01"}}},
+		},
+		{
+			name:     "unknown preset lists presets",
+			userPath: userPath,
+			preset:   "nope",
+			wantErr:  `unknown preset "nope", must be one of: deploy, release`,
+		},
+		{
+			name:    "no config files",
+			preset:  "deploy",
+			wantErr: "no presets defined in any config file (none found)",
+		},
+		{
+			name: "nothing configured",
+			want: parser.Options{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadFiles(tt.userPath, false, tt.projectPath)
+			require.NoError(t, err)
+
+			got, err := cfg.Resolve(tt.preset)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Title, got.Title)
+			assert.Equal(t, tt.want.Subtitle, got.Subtitle)
+			assert.Equal(t, tt.want.Footer, got.Footer)
+			assert.Equal(t, tt.want.Width, got.Width)
+			assert.Equal(t, tt.want.BorderStyle, got.BorderStyle)
+			assert.Empty(t, got.KVFlags, "rows are merged as split pairs")
+			assert.Equal(t, tt.want.KVPairs, got.KVPairs)
+			assert.Equal(t, tt.want.Rules, got.Rules)
+			assert.Equal(t, tt.want.Columns, got.Columns)
+			assert.Equal(t, tt.want.Layout, got.Layout)
+			assert.Equal(t, tt.want.Compact, got.Compact)
+		})
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing user file is ignored", func(t *testing.T) {
+		cfg, err := LoadFiles(filepath.Join(dir, "missing.yaml"), false, "")
+		require.NoError(t, err)
+		assert.Nil(t, cfg.User)
+		assert.Empty(t, cfg.UserPath)
+	})
+
+	t.Run("missing explicit file is an error", func(t *testing.T) {
+		_, err := LoadFiles(filepath.Join(dir, "missing.yaml"), true, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open config file")
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		cfg, err := LoadFiles(writeFile(t, dir, "empty.yaml", ""), true, "")
+		require.NoError(t, err)
+		assert.NotNil(t, cfg.User)
+	})
+
+	t.Run("unknown keys are rejected", func(t *testing.T) {
+		_, err := LoadFiles(writeFile(t, dir, "typo.yaml", "defaults:\n  border_style: thick\n"), false, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "border_style")
+	})
+}
+
+func TestUserPath(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantPath     string
+		wantExplicit bool
+	}{
+		{
+			name:         "BOXED_CONFIG wins",
+			env:          map[string]string{"BOXED_CONFIG": "/etc/boxed.yaml", "XDG_CONFIG_HOME": "/xdg", "HOME": "/home/me"},
+			wantPath:     "/etc/boxed.yaml",
+			wantExplicit: true,
+		},
+		{
+			name:     "XDG_CONFIG_HOME",
+			env:      map[string]string{"XDG_CONFIG_HOME": "/xdg", "HOME": "/home/me"},
+			wantPath: "/xdg/boxed/config.yaml",
+		},
+		{
+			name:     "HOME fallback",
+			env:      map[string]string{"HOME": "/home/me"},
+			wantPath: "/home/me/.config/boxed/config.yaml",
+		},
+		{
+			name: "no home",
+			env:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, explicit := UserPath(func(key string) string { return tt.env[key] })
+			assert.Equal(t, tt.wantPath, path)
+			assert.Equal(t, tt.wantExplicit, explicit)
+		})
+	}
+}
+
+func TestProjectPath(t *testing.T) {
+	root := t.TempDir()
+	path := writeFile(t, root, filepath.Join("repo", ProjectFileName), "")
+	nested := filepath.Join(root, "repo", "scripts", "deploy")
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+
+	assert.Equal(t, path, ProjectPath(nested))
+	assert.Equal(t, path, ProjectPath(filepath.Join(root, "repo")))
+	assert.Empty(t, ProjectPath(root))
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestMerge(t *testing.T) {
+	opts := parser.Options{Title: "CLI", KVFlags: []string{"B=2"}, KVPairs: []box.KV{{Key: "D", Value: "4"}}, Rules: []string{"B>1:warning"}}
+	fallback := parser.Options{Title: "Config", Footer: "Config footer", Width: 70, BorderStyle: "double", Locale: "de", KVFlags: []string{"A=1"},
+		KVPairs: []box.KV{{Key: "C", Value: "3"}},
+		Layout:  box.KVLayout{Separator: ":"}, Compact: boolPtr(true), Raw: boolPtr(true)}
+
+	got := Merge(opts, fallback)
+
+	assert.Equal(t, "CLI", got.Title)
+	assert.Equal(t, "Config footer", got.Footer)
+	assert.Equal(t, 70, got.Width)
+	assert.Equal(t, "double", got.BorderStyle)
+	assert.Equal(t, "de", got.Locale)
+	assert.Equal(t, box.KVLayout{Separator: ":"}, got.Layout)
+	assert.Equal(t, boolPtr(true), got.Compact)
+	assert.Equal(t, boolPtr(true), got.Raw)
+	assert.Nil(t, got.AlignNumbers, "unset in both layers")
+	assert.Empty(t, got.KVFlags)
+	assert.Equal(t, []box.KV{{Key: "A", Value: "1"}, {Key: "C", Value: "3"}, {Key: "B", Value: "2"}, {Key: "D", Value: "4"}}, got.KVPairs)
+	assert.Equal(t, []string{"B>1:warning"}, got.Rules)
+	assert.Equal(t, []string{"A=1"}, fallback.KVFlags, "fallback must not be modified")
+	assert.Equal(t, []box.KV{{Key: "C", Value: "3"}}, fallback.KVPairs, "fallback must not be modified")
+}
+
+func TestMergeSwitches(t *testing.T) {
+	fallback := parser.Options{AlignNumbers: boolPtr(true), Compact: boolPtr(true), Raw: boolPtr(true)}
+
+	got := Merge(parser.Options{Compact: boolPtr(false), Raw: boolPtr(false)}, fallback)
+
+	assert.Equal(t, boolPtr(true), got.AlignNumbers, "left unset, so the fallback applies")
+	assert.Equal(t, boolPtr(false), got.Compact, "a higher layer can turn a switch off")
+	assert.Equal(t, boolPtr(false), got.Raw)
+}
+
+func TestMergeKVs(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     parser.Options
+		fallback parser.Options
+		want     []box.KV
+	}{
+		{
+			name:     "flag replaces flag in place",
+			opts:     parser.Options{KVFlags: []string{"Env=prod"}},
+			fallback: parser.Options{KVFlags: []string{"Env=staging,Region=eu"}},
+			want:     []box.KV{{Key: "Env", Value: "prod"}, {Key: "Region", Value: "eu"}},
+		},
+		{
+			name:     "flag replaces JSON pair",
+			opts:     parser.Options{KVFlags: []string{"Env=prod"}},
+			fallback: parser.Options{KVPairs: []box.KV{{Key: "Env", Value: "staging"}, {Key: "Region", Value: "eu"}}},
+			want:     []box.KV{{Key: "Env", Value: "prod"}, {Key: "Region", Value: "eu"}},
+		},
+		{
+			name:     "template pair replaces config flag",
+			opts:     parser.Options{KVPairs: []box.KV{{Key: "Note", Value: "a,b=c"}}},
+			fallback: parser.Options{KVFlags: []string{"Note=default", "Host=build-01"}},
+			want:     []box.KV{{Key: "Note", Value: "a,b=c"}, {Key: "Host", Value: "build-01"}},
+		},
+		{
+			name:     "statuses stay with the value",
+			opts:     parser.Options{KVFlags: []string{"DB=down!error"}},
+			fallback: parser.Options{KVFlags: []string{"DB=up!success"}},
+			want:     []box.KV{{Key: "DB", Value: "down!error"}},
+		},
+		{
+			name:     "key repeated in the higher layer",
+			opts:     parser.Options{KVFlags: []string{"Step=build", "Step=test"}},
+			fallback: parser.Options{KVFlags: []string{"Step=checkout"}},
+			want:     []box.KV{{Key: "Step", Value: "build"}, {Key: "Step", Value: "test"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge(tt.opts, tt.fallback)
+			assert.Empty(t, got.KVFlags)
+			assert.Equal(t, tt.want, got.KVPairs)
+		})
+	}
+
+	got := Merge(parser.Options{KVFlags: []string{"invalid"}}, parser.Options{KVFlags: []string{"A=1"}})
+	assert.Equal(t, []string{"A=1", "invalid"}, got.KVFlags, "rows that don't parse are left for ParseBox to report")
+}
+
+func TestResolveSwitches(t *testing.T) {
+	userPath := writeFile(t, t.TempDir(), "user.yaml", `
+defaults:
+  align-numbers: true
+  compact: true
+  raw: true
+presets:
+  plain:
+    compact: false
+    raw: false
+`)
+	cfg, err := LoadFiles(userPath, true, "")
+	require.NoError(t, err)
+
+	got, err := cfg.Resolve("plain")
+	require.NoError(t, err)
+	assert.Equal(t, boolPtr(true), got.AlignNumbers)
+	assert.Equal(t, boolPtr(false), got.Compact, "a preset can turn off a default")
+	assert.Equal(t, boolPtr(false), got.Raw)
+}
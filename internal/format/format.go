@@ -0,0 +1,240 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds are the value types that can be written as "@<kind>:<value>" in --kv or as
+// {"<kind>": value} in JSON.
+var Kinds = []string{"bytes", "duration", "number", "percent", "ago"}
+
+// IsKind reports whether kind is one of Kinds.
+func IsKind(kind string) bool {
+	return slices.Contains(Kinds, kind)
+}
+
+// Value is a parsed typed value. Number is its canonical numeric form (bytes,
+// seconds, the number itself, a percentage, seconds since the timestamp), which is
+// what rules compare against: "Backup>86400:error" works on an @ago value no matter
+// how the age is displayed.
+type Value struct {
+	Kind   string
+	Number float64
+	// Time is set for "ago" values, which are displayed relative to Now.
+	Time time.Time
+	Now  time.Time
+}
+
+// Parse reads the argument of a typed value. now anchors "ago" values.
+func Parse(kind, arg string, now time.Time) (Value, error) {
+	arg = strings.TrimSpace(arg)
+	v := Value{Kind: kind, Now: now}
+
+	var err error
+	switch kind {
+	case "bytes", "number":
+		v.Number, err = parseNumber(arg)
+	case "duration":
+		var d time.Duration
+		d, err = parseDuration(arg)
+		v.Number = d.Seconds()
+	case "percent":
+		v.Number, err = parsePercent(arg)
+	case "ago":
+		v.Time, err = parseTime(arg)
+		v.Number = math.Floor(now.Sub(v.Time).Seconds())
+	default:
+		return Value{}, fmt.Errorf("unknown format %q, must be one of: %s", kind, strings.Join(Kinds, ", "))
+	}
+	if err != nil {
+		return Value{}, fmt.Errorf("invalid %s value %q: %w", kind, arg, err)
+	}
+	return v, nil
+}
+
+// Format renders the value for display using the locale's separators.
+func (v Value) Format(l Locale) string {
+	switch v.Kind {
+	case "bytes":
+		return Bytes(v.Number, l)
+	case "duration":
+		return Duration(time.Duration(v.Number * float64(time.Second)))
+	case "number":
+		return Number(v.Number, l)
+	case "percent":
+		return Percent(v.Number, l)
+	case "ago":
+		return Ago(v.Time, v.Now)
+	}
+	return strconv.FormatFloat(v.Number, 'f', -1, 64)
+}
+
+func parseNumber(s string) (float64, error) {
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("not a number")
+	}
+	return n, nil
+}
+
+// parsePercent accepts a percentage ("45.2") or a fraction of a total ("3/4"), which
+// saves scripts from doing floating point arithmetic in bash.
+func parsePercent(s string) (float64, error) {
+	part, total, isRatio := strings.Cut(s, "/")
+	n, err := parseNumber(part)
+	if err != nil || !isRatio {
+		return n, err
+	}
+	d, err := parseNumber(total)
+	if err != nil {
+		return 0, err
+	}
+	if d == 0 {
+		return 0, fmt.Errorf("total is zero")
+	}
+	return n / d * 100, nil
+}
+
+// parseDuration accepts Go durations ("1h30m", "154s"), days ("2d") and plain
+// numbers of seconds, which is what `date +%s` arithmetic produces.
+func parseDuration(s string) (time.Duration, error) {
+	if n, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(n * float64(time.Second)), nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.ParseFloat(days, 64)
+		if err != nil {
+			return 0, fmt.Errorf("not a duration")
+		}
+		return time.Duration(n * 24 * float64(time.Hour)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("not a duration")
+	}
+	return d, nil
+}
+
+// timeLayouts are tried in order; RFC 3339 is what `date -Iseconds` and most JSON
+// emit, the others are common in logs.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// parseTime accepts the layouts above or Unix seconds (`date +%s`).
+func parseTime(s string) (time.Time, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(n, 0), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("not an RFC 3339 timestamp or Unix time")
+}
+
+// byteUnits are binary (IEC) units, matching what df -h and du -h report.
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// Bytes formats a size with one decimal in the largest unit that keeps it at or
+// above 1, e.g. 2576980377 → "2.4 GiB". Whole bytes are shown without decimals.
+// The unit is picked after rounding, so 1048575 is "1 MiB" rather than "1,024 KiB".
+func Bytes(n float64, l Locale) string {
+	unit, decimals := 0, 0
+	for math.Abs(round(n, decimals)) >= 1024 && unit < len(byteUnits)-1 {
+		n /= 1024
+		unit, decimals = unit+1, 1
+	}
+	return l.formatNumber(n, decimals) + " " + byteUnits[unit]
+}
+
+// Number adds thousands separators and keeps the decimals given.
+func Number(n float64, l Locale) string {
+	return l.formatNumber(n, -1)
+}
+
+// Percent rounds to one decimal, e.g. 45.25 → "45.3%".
+func Percent(n float64, l Locale) string {
+	return l.formatNumber(n, 1) + l.percentSign
+}
+
+// durationUnits go from largest to smallest; durations show the two most
+// significant, which is as precise as a status box needs.
+var durationUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+}
+
+// Duration formats a duration as its two most significant units, e.g. 154s →
+// "2m 34s" and 90000s → "1d 1h". Durations under a second are shown in ms.
+func Duration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	if d < time.Second {
+		// Under a millisecond rounds to 0, which has no sign.
+		if d < time.Millisecond {
+			return "0s"
+		}
+		return sign + strconv.FormatInt(d.Milliseconds(), 10) + "ms"
+	}
+
+	for i, unit := range durationUnits {
+		if d < unit.size {
+			continue
+		}
+		parts := []string{strconv.FormatInt(int64(d/unit.size), 10) + unit.name}
+		// The second unit is always the next smaller one, so "1h 0m 5s" is "1h"
+		// rather than the misleading "1h 5s".
+		if i+1 < len(durationUnits) {
+			next := durationUnits[i+1]
+			if n := d % unit.size / next.size; n > 0 {
+				parts = append(parts, strconv.FormatInt(int64(n), 10)+next.name)
+			}
+		}
+		return sign + strings.Join(parts, " ")
+	}
+	return sign + d.String()
+}
+
+// agoUnits are used for relative times; only the largest unit is shown, the way
+// "3h ago" reads in a status box.
+var agoUnits = []struct {
+	size time.Duration
+	name string
+}{
+	{365 * 24 * time.Hour, "y"},
+	{30 * 24 * time.Hour, "mo"},
+	{24 * time.Hour, "d"},
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+}
+
+// Ago formats t relative to now: "3h ago", "in 2d", or "just now" within a minute.
+func Ago(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	for _, unit := range agoUnits {
+		if d >= unit.size {
+			amount := strconv.FormatInt(int64(d/unit.size), 10) + unit.name
+			if future {
+				return "in " + amount
+			}
+			return amount + " ago"
+		}
+	}
+	return "just now"
+}
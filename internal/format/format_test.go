@@ -0,0 +1,111 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParseAndFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		arg        string
+		locale     string
+		wantNumber float64
+		want       string
+	}{
+		{name: "bytes", kind: "bytes", arg: "2576980377", wantNumber: 2576980377, want: "2.4 GiB"},
+		{name: "whole unit", kind: "bytes", arg: "1048576", wantNumber: 1048576, want: "1 MiB"},
+		{name: "small bytes", kind: "bytes", arg: "512", wantNumber: 512, want: "512 B"},
+		{name: "rounds up to the next unit", kind: "bytes", arg: "1048575", wantNumber: 1048575, want: "1 MiB"},
+		{name: "whole bytes round up to KiB", kind: "bytes", arg: "1023.6", wantNumber: 1023.6, want: "1 KiB"},
+		{name: "negative bytes", kind: "bytes", arg: "-2048", wantNumber: -2048, want: "-2 KiB"},
+		{name: "negative bytes rounding to zero", kind: "bytes", arg: "-0.3", wantNumber: -0.3, want: "0 B"},
+		{name: "bytes in German", kind: "bytes", arg: "2576980377", locale: "de", wantNumber: 2576980377, want: "2,4 GiB"},
+		{name: "duration in seconds", kind: "duration", arg: "154s", wantNumber: 154, want: "2m 34s"},
+		{name: "duration as a number", kind: "duration", arg: "3725", wantNumber: 3725, want: "1h 2m"},
+		{name: "duration in days", kind: "duration", arg: "1.5d", wantNumber: 129600, want: "1d 12h"},
+		{name: "skipped unit is not shown", kind: "duration", arg: "1h0m5s", wantNumber: 3605, want: "1h"},
+		{name: "sub-second duration", kind: "duration", arg: "250ms", wantNumber: 0.25, want: "250ms"},
+		{name: "negative duration rounding to zero", kind: "duration", arg: "-400us", wantNumber: -0.0004, want: "0s"},
+		{name: "number", kind: "number", arg: "1234567.5", wantNumber: 1234567.5, want: "1,234,567.5"},
+		{name: "negative number", kind: "number", arg: "-1234", wantNumber: -1234, want: "-1,234"},
+		{name: "number in French", kind: "number", arg: "1234567.5", locale: "fr_FR.UTF-8", wantNumber: 1234567.5, want: "1\u00a0234\u00a0567,5"},
+		{name: "number in Swiss German", kind: "number", arg: "1234567.5", locale: "de-CH", wantNumber: 1234567.5, want: "1’234’567.5"},
+		{name: "percent", kind: "percent", arg: "45.25", wantNumber: 45.25, want: "45.3%"},
+		{name: "negative percent rounding to zero", kind: "percent", arg: "-0.04", wantNumber: -0.04, want: "0%"},
+		{name: "percent from a ratio", kind: "percent", arg: "3/4", wantNumber: 75, want: "75%"},
+		{name: "percent in German", kind: "percent", arg: "45.25", locale: "de", wantNumber: 45.25, want: "45,3\u00a0%"},
+		{name: "ago", kind: "ago", arg: "2024-05-01T09:00:00Z", wantNumber: 10800, want: "3h ago"},
+		{name: "ago from Unix time", kind: "ago", arg: "1714557600", wantNumber: 7200, want: "2h ago"},
+		{name: "ago in days", kind: "ago", arg: "2024-04-28", wantNumber: 302400, want: "3d ago"},
+		{name: "ago in the future", kind: "ago", arg: "2024-05-03T12:00:00Z", wantNumber: -172800, want: "in 2d"},
+		{name: "just now", kind: "ago", arg: "2024-05-01T11:59:30Z", wantNumber: 30, want: "just now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locale, err := ParseLocale(tt.locale)
+			require.NoError(t, err)
+
+			v, err := Parse(tt.kind, tt.arg, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, v.Number)
+			assert.Equal(t, tt.want, v.Format(locale))
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		kind    string
+		arg     string
+		wantErr string
+	}{
+		{kind: "bytes", arg: "2GB", wantErr: `invalid bytes value "2GB": not a number`},
+		{kind: "number", arg: "NaN", wantErr: `invalid number value "NaN": not a number`},
+		{kind: "duration", arg: "soon", wantErr: `invalid duration value "soon": not a duration`},
+		{kind: "percent", arg: "1/0", wantErr: `invalid percent value "1/0": total is zero`},
+		{kind: "ago", arg: "yesterday", wantErr: `invalid ago value "yesterday": not an RFC 3339 timestamp or Unix time`},
+		{kind: "size", arg: "1", wantErr: `unknown format "size", must be one of: bytes, duration, number, percent, ago`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind+" "+tt.arg, func(t *testing.T) {
+			_, err := Parse(tt.kind, tt.arg, now)
+			require.Error(t, err)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "", want: "en"},
+		{input: "C", want: "en"},
+		{input: "en_US.UTF-8", want: "en"},
+		{input: "de-AT", want: "de"},
+		{input: "de_CH.UTF-8", want: "de-CH"},
+		{input: "sv_SE@euro", want: "sv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			locale, err := ParseLocale(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, locale.Name)
+		})
+	}
+
+	_, err := ParseLocale("xx")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported locale "xx", must be one of: cs, da, de`)
+}
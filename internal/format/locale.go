@@ -0,0 +1,117 @@
+package format
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// nbsp keeps grouped digits and "45 %" together when a value wraps.
+const nbsp = "\u00a0"
+
+// Locale holds the separators used to display numbers.
+type Locale struct {
+	Name        string
+	thousands   string
+	decimal     string
+	percentSign string
+}
+
+var (
+	english = Locale{Name: "en", thousands: ",", decimal: ".", percentSign: "%"}
+	// Languages grouped by the conventions CLDR lists for them; only separators
+	// differ, unit names stay the same everywhere.
+	locales = map[string]Locale{
+		"en": english,
+		"ja": {Name: "ja", thousands: ",", decimal: ".", percentSign: "%"},
+		"ko": {Name: "ko", thousands: ",", decimal: ".", percentSign: "%"},
+		"zh": {Name: "zh", thousands: ",", decimal: ".", percentSign: "%"},
+		"de": {Name: "de", thousands: ".", decimal: ",", percentSign: nbsp + "%"},
+		"es": {Name: "es", thousands: ".", decimal: ",", percentSign: nbsp + "%"},
+		"da": {Name: "da", thousands: ".", decimal: ",", percentSign: nbsp + "%"},
+		"it": {Name: "it", thousands: ".", decimal: ",", percentSign: "%"},
+		"nl": {Name: "nl", thousands: ".", decimal: ",", percentSign: "%"},
+		"pt": {Name: "pt", thousands: ".", decimal: ",", percentSign: "%"},
+		"tr": {Name: "tr", thousands: ".", decimal: ",", percentSign: "%"},
+		"fr": {Name: "fr", thousands: nbsp, decimal: ",", percentSign: nbsp + "%"},
+		"sv": {Name: "sv", thousands: nbsp, decimal: ",", percentSign: nbsp + "%"},
+		"nb": {Name: "nb", thousands: nbsp, decimal: ",", percentSign: nbsp + "%"},
+		"fi": {Name: "fi", thousands: nbsp, decimal: ",", percentSign: nbsp + "%"},
+		"pl": {Name: "pl", thousands: nbsp, decimal: ",", percentSign: "%"},
+		"cs": {Name: "cs", thousands: nbsp, decimal: ",", percentSign: nbsp + "%"},
+		"ru": {Name: "ru", thousands: nbsp, decimal: ",", percentSign: nbsp + "%"},
+		"uk": {Name: "uk", thousands: nbsp, decimal: ",", percentSign: "%"},
+	}
+	// regional overrides where a country differs from its language's default
+	regions = map[string]Locale{
+		"de-ch": {Name: "de-CH", thousands: "’", decimal: ".", percentSign: "%"},
+		"pt-br": {Name: "pt-BR", thousands: ".", decimal: ",", percentSign: "%"},
+		"es-mx": {Name: "es-MX", thousands: ",", decimal: ".", percentSign: "%"},
+	}
+)
+
+// ParseLocale accepts language tags ("de", "de-CH") and POSIX locale names
+// ("de_DE.UTF-8"), so $LANG can be passed as-is. Empty, "C" and "POSIX" mean English.
+func ParseLocale(s string) (Locale, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	name, _, _ = strings.Cut(name, ".")
+	name, _, _ = strings.Cut(name, "@")
+	name = strings.ReplaceAll(name, "_", "-")
+	if name == "" || name == "c" || name == "posix" {
+		return english, nil
+	}
+
+	if l, ok := regions[name]; ok {
+		return l, nil
+	}
+	language, _, _ := strings.Cut(name, "-")
+	if l, ok := locales[language]; ok {
+		return l, nil
+	}
+
+	supported := make([]string, 0, len(locales))
+	for language := range locales {
+		supported = append(supported, language)
+	}
+	slices.Sort(supported)
+	return Locale{}, fmt.Errorf("unsupported locale %q, must be one of: %s", s, strings.Join(supported, ", "))
+}
+
+// formatNumber groups the integer part in threes and uses the locale's decimal
+// separator. decimals < 0 keeps as many as needed; otherwise the value is rounded
+// and trailing zeros are dropped, so 2.0 GiB reads "2 GiB". A value that rounds to
+// zero is shown as "0", never "-0".
+func (l Locale) formatNumber(n float64, decimals int) string {
+	if decimals >= 0 {
+		n = round(n, decimals)
+	}
+	if n == 0 {
+		n = 0 // drops the sign of -0
+	}
+	text := strconv.FormatFloat(math.Abs(n), 'f', -1, 64)
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	var out strings.Builder
+	if n < 0 {
+		out.WriteString("-")
+	}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			out.WriteString(l.thousands)
+		}
+		out.WriteRune(digit)
+	}
+	if fraction != "" {
+		out.WriteString(l.decimal)
+		out.WriteString(fraction)
+	}
+	return out.String()
+}
+
+// round rounds n to the given number of decimals.
+func round(n float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(n*scale) / scale
+}
@@ -0,0 +1,88 @@
+package gitinfo
+
+import (
+	"fmt"
+	"strings"
+
+	"boxed/internal/box"
+)
+
+// shortHashLength matches git's default abbreviation for small repositories.
+const shortHashLength = 7
+
+// SummaryBox shows a summary as a box titled with the repository name. A dirty tree
+// is a warning and merge conflicts an error; being behind the upstream is only shown,
+// since it says nothing about the checkout itself.
+func SummaryBox(s Summary, name string) *box.Box {
+	changes := box.KV{Key: "Changes", Value: "clean", Status: box.Success}
+	if s.Dirty() {
+		parts := []string{
+			fmt.Sprintf("%d staged", s.Staged),
+			fmt.Sprintf("%d modified", s.Modified),
+			fmt.Sprintf("%d untracked", s.Untracked),
+		}
+		changes.Status = box.Warning
+		if s.Conflicted > 0 {
+			parts = append(parts, fmt.Sprintf("%d conflicted", s.Conflicted))
+			changes.Status = box.Error
+		}
+		changes.Value = strings.Join(parts, ", ")
+	}
+
+	b := &box.Box{
+		Type:     changes.Status,
+		Title:    "Git Summary",
+		Subtitle: name,
+		KVPairs: []box.KV{
+			{Key: "Branch", Value: s.branch()},
+			{Key: "Sync", Value: s.sync()},
+			changes,
+			{Key: "Last commit", Value: s.LastCommit.String()},
+			{Key: "Tag", Value: orNone(s.LastTag)},
+		},
+		Footer: s.Remote,
+	}
+	return b
+}
+
+func (s Status) branch() string {
+	if s.Branch != "" {
+		return s.Branch
+	}
+	if s.Commit == "" {
+		return "detached"
+	}
+	return "detached at " + shortHash(s.Commit)
+}
+
+func (s Status) sync() string {
+	switch {
+	case s.Upstream == "":
+		return "no upstream"
+	case s.Ahead == 0 && s.Behind == 0:
+		return "up to date with " + s.Upstream
+	}
+	return fmt.Sprintf("%d ahead, %d behind %s", s.Ahead, s.Behind, s.Upstream)
+}
+
+// String formats the commit as "<hash> <subject> (<author>, <when>)".
+func (c Commit) String() string {
+	if c.Hash == "" {
+		return "none"
+	}
+	return fmt.Sprintf("%s %s (%s, %s)", c.Hash, c.Subject, c.Author, c.When)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+	return hash
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
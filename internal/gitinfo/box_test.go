@@ -0,0 +1,94 @@
+package gitinfo
+
+import (
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryBox(t *testing.T) {
+	commit := Commit{Hash: "abc1234", Subject: "Fix login", Author: "Dana", When: "3 hours ago"}
+
+	tests := []struct {
+		name        string
+		summary     Summary
+		wantType    box.BoxType
+		wantBranch  string
+		wantSync    string
+		wantChanges string
+		wantCommit  string
+		wantTag     string
+	}{
+		{
+			name:        "clean and up to date",
+			summary:     Summary{Status: Status{Branch: "main", Commit: "abc1234def", Upstream: "origin/main"}, LastCommit: commit, LastTag: "v1.2.0"},
+			wantType:    box.Success,
+			wantBranch:  "main",
+			wantSync:    "up to date with origin/main",
+			wantChanges: "clean",
+			wantCommit:  "abc1234 Fix login (Dana, 3 hours ago)",
+			wantTag:     "v1.2.0",
+		},
+		{
+			name:        "dirty",
+			summary:     Summary{Status: Status{Branch: "main", Upstream: "origin/main", Ahead: 2, Behind: 1, Staged: 1, Modified: 2, Untracked: 3}, LastCommit: commit},
+			wantType:    box.Warning,
+			wantBranch:  "main",
+			wantSync:    "2 ahead, 1 behind origin/main",
+			wantChanges: "1 staged, 2 modified, 3 untracked",
+			wantCommit:  "abc1234 Fix login (Dana, 3 hours ago)",
+			wantTag:     "none",
+		},
+		{
+			name:        "conflicts",
+			summary:     Summary{Status: Status{Branch: "main", Conflicted: 2}, LastCommit: commit},
+			wantType:    box.Error,
+			wantBranch:  "main",
+			wantSync:    "no upstream",
+			wantChanges: "0 staged, 0 modified, 0 untracked, 2 conflicted",
+			wantCommit:  "abc1234 Fix login (Dana, 3 hours ago)",
+			wantTag:     "none",
+		},
+		{
+			name:        "detached CI checkout",
+			summary:     Summary{Status: Status{Commit: "1234567890abcdef"}, LastCommit: commit},
+			wantType:    box.Success,
+			wantBranch:  "detached at 1234567",
+			wantSync:    "no upstream",
+			wantChanges: "clean",
+			wantCommit:  "abc1234 Fix login (Dana, 3 hours ago)",
+			wantTag:     "none",
+		},
+		{
+			name:        "no commits",
+			summary:     Summary{Status: Status{Branch: "main"}},
+			wantType:    box.Success,
+			wantBranch:  "main",
+			wantSync:    "no upstream",
+			wantChanges: "clean",
+			wantCommit:  "none",
+			wantTag:     "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := SummaryBox(tt.summary, "boxed")
+
+			assert.Equal(t, tt.wantType, b.Type)
+			assert.Equal(t, "Git Summary", b.Title)
+			assert.Equal(t, "boxed", b.Subtitle)
+			values := map[string]string{}
+			for _, kv := range b.KVPairs {
+				values[kv.Key] = kv.Value
+			}
+			assert.Equal(t, tt.wantBranch, values["Branch"])
+			assert.Equal(t, tt.wantSync, values["Sync"])
+			assert.Equal(t, tt.wantChanges, values["Changes"])
+			assert.Equal(t, tt.wantCommit, values["Last commit"])
+			assert.Equal(t, tt.wantTag, values["Tag"])
+		})
+	}
+}
@@ -0,0 +1,372 @@
+// Package gitinfo summarizes a git working tree. It reads the repository itself with
+// go-git rather than running the git CLI, so the summary works in minimal CI images
+// and containers that have a checkout but no git binary.
+package gitinfo
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"boxed/internal/format"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Status is the state of the working tree and its branch.
+type Status struct {
+	// Branch is empty when HEAD is detached.
+	Branch string
+	// Commit is the full hash of HEAD; empty before the first commit.
+	Commit string
+	// Upstream is the tracking branch, e.g. origin/main; empty when there is none.
+	Upstream string
+	Ahead    int
+	Behind   int
+
+	Staged     int
+	Modified   int
+	Untracked  int
+	Conflicted int
+}
+
+// Dirty reports whether anything differs from HEAD, including untracked files.
+func (s Status) Dirty() bool {
+	return s.Staged+s.Modified+s.Untracked+s.Conflicted > 0
+}
+
+// Commit describes the last commit.
+type Commit struct {
+	Hash    string
+	Subject string
+	Author  string
+	// When is relative, e.g. "3h ago".
+	When string
+}
+
+// Summary is everything shown by the git box.
+type Summary struct {
+	Status
+	LastCommit Commit
+	// LastTag is the most recent tag reachable from HEAD; empty when there is none.
+	LastTag string
+	// Remote is the URL of origin without credentials; empty when there is no such
+	// remote.
+	Remote string
+}
+
+// Read summarizes the repository containing dir. It only reads local state, so it
+// works in CI checkouts without network access; ahead/behind compares against the
+// last fetched upstream. In shallow clones history ends where the clone does, as it
+// does for git.
+func Read(ctx context.Context, dir string) (Summary, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true, EnableDotGitCommonDir: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return Summary{}, fmt.Errorf("not a git repository (or any of the parent directories): %s", dir)
+	}
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	var summary Summary
+	if err := readHead(repo, &summary.Status); err != nil {
+		return Summary{}, err
+	}
+	if err := readChanges(repo, &summary.Status); err != nil {
+		return Summary{}, err
+	}
+
+	cfg, err := repo.Config()
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to read git config: %w", err)
+	}
+	if remote, ok := cfg.Remotes["origin"]; ok && len(remote.URLs) > 0 {
+		summary.Remote = withoutCredentials(remote.URLs[0])
+	}
+
+	// The rest needs a commit: a new repository has none, and most have no tags
+	// or upstream.
+	if summary.Commit == "" {
+		return summary, nil
+	}
+	head := plumbing.NewHash(summary.Commit)
+
+	commit, err := repo.CommitObject(head)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+	subject, _, _ := strings.Cut(commit.Message, "\n")
+	summary.LastCommit = Commit{
+		Hash:    shortHash(summary.Commit),
+		Subject: subject,
+		Author:  commit.Author.Name,
+		When:    format.Ago(commit.Committer.When, time.Now()),
+	}
+
+	if summary.LastTag, err = lastTag(ctx, repo, head); err != nil {
+		return Summary{}, err
+	}
+
+	if branch, ok := cfg.Branches[summary.Branch]; ok && summary.Branch != "" && branch.Remote != "" && branch.Merge != "" {
+		summary.Upstream = branch.Remote + "/" + branch.Merge.Short()
+		// An upstream that was never fetched (or was deleted) has nothing to compare.
+		upstream, err := repo.Reference(plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short()), true)
+		if err == nil {
+			if summary.Ahead, summary.Behind, err = aheadBehind(ctx, repo, head, upstream.Hash()); err != nil {
+				return Summary{}, err
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// readHead sets the branch and commit. HEAD names a branch even before its first
+// commit, when the branch itself doesn't exist yet.
+func readHead(repo *git.Repository, status *Status) error {
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	if head.Type() == plumbing.SymbolicReference {
+		status.Branch = head.Target().Short()
+	}
+
+	resolved, err := repo.Reference(plumbing.HEAD, true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	status.Commit = resolved.Hash().String()
+	return nil
+}
+
+// readChanges counts changed files the way `git status` does: a file can be both
+// staged and modified. Global and system excludes are added to the repository's
+// own ignore rules so untracked counts match.
+func readChanges(repo *git.Repository, status *Status) error {
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to open working tree: %w", err)
+	}
+	root := osfs.New("/")
+	for _, load := range []func(billy.Filesystem) ([]gitignore.Pattern, error){gitignore.LoadGlobalPatterns, gitignore.LoadSystemPatterns} {
+		// Unreadable excludes files only make more files show as untracked.
+		if patterns, err := load(root); err == nil {
+			worktree.Excludes = append(worktree.Excludes, patterns...)
+		}
+	}
+
+	files, err := worktree.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read working tree status: %w", err)
+	}
+	for _, file := range files {
+		switch {
+		case file.Staging == git.UpdatedButUnmerged || file.Worktree == git.UpdatedButUnmerged:
+			status.Conflicted++
+		case file.Staging == git.Untracked:
+			status.Untracked++
+		default:
+			if file.Staging != git.Unmodified {
+				status.Staged++
+			}
+			if file.Worktree != git.Unmodified {
+				status.Modified++
+			}
+		}
+	}
+	return nil
+}
+
+// lastTag finds the tag nearest to head, like `git describe --tags --abbrev=0`.
+// Tags on the same commit are sorted so the choice is stable.
+func lastTag(ctx context.Context, repo *git.Repository, head plumbing.Hash) (string, error) {
+	refs, err := repo.Tags()
+	if err != nil {
+		return "", fmt.Errorf("failed to list tags: %w", err)
+	}
+	tags := map[plumbing.Hash][]string{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		target := ref.Hash()
+		// Annotated tags point to a tag object; lightweight tags to the commit.
+		if tag, err := repo.TagObject(target); err == nil {
+			if tag.TargetType != plumbing.CommitObject {
+				return nil
+			}
+			target = tag.Target
+		}
+		tags[target] = append(tags[target], ref.Name().Short())
+		return nil
+	})
+	if err != nil || len(tags) == 0 {
+		return "", err
+	}
+
+	var found string
+	err = walk(ctx, repo, []plumbing.Hash{head}, func(c *object.Commit) error {
+		if names := tags[c.Hash]; len(names) > 0 {
+			sort.Strings(names)
+			found = names[len(names)-1]
+			return errStopWalk
+		}
+		return nil
+	})
+	return found, err
+}
+
+// Sides of history a commit is reachable from, for aheadBehind.
+const (
+	fromHead = 1 << iota
+	fromUpstream
+	fromBoth = fromHead | fromUpstream
+)
+
+// aheadBehind counts the commits only reachable from head and only reachable from
+// upstream, like `git rev-list --left-right --count`. Both sides are walked together,
+// newest first, marking each commit with the sides that reach it. Once every commit
+// left to visit is reachable from both, the rest is shared history, so the walk ends
+// at the merge base rather than at the first commit of a long-lived branch.
+func aheadBehind(ctx context.Context, repo *git.Repository, head, upstream plumbing.Hash) (ahead, behind int, err error) {
+	sides := map[plumbing.Hash]int{}
+	visited := map[plumbing.Hash]bool{}
+	queue := &commitQueue{}
+	// pending counts the queued commits not yet known to be reachable from both sides.
+	pending := 0
+
+	enqueue := func(hash plumbing.Hash, side int) error {
+		if visited[hash] {
+			return nil
+		}
+		if old, queued := sides[hash]; queued {
+			sides[hash] = old | side
+			if old != fromBoth && old|side == fromBoth {
+				pending--
+			}
+			return nil
+		}
+		commit, err := repo.CommitObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			visited[hash] = true
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read commit %s: %w", hash, err)
+		}
+		sides[hash] = side
+		heap.Push(queue, commit)
+		if side != fromBoth {
+			pending++
+		}
+		return nil
+	}
+
+	if err := enqueue(head, fromHead); err != nil {
+		return 0, 0, err
+	}
+	if err := enqueue(upstream, fromUpstream); err != nil {
+		return 0, 0, err
+	}
+	for pending > 0 {
+		if err := ctx.Err(); err != nil {
+			return 0, 0, err
+		}
+		commit := heap.Pop(queue).(*object.Commit)
+		visited[commit.Hash] = true
+		side := sides[commit.Hash]
+		switch side {
+		case fromHead:
+			ahead++
+			pending--
+		case fromUpstream:
+			behind++
+			pending--
+		}
+		for _, parent := range commit.ParentHashes {
+			if err := enqueue(parent, side); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	return ahead, behind, nil
+}
+
+// commitQueue orders commits newest first by committer date, so a commit is
+// usually visited after every commit that has it as a parent.
+type commitQueue []*object.Commit
+
+func (q commitQueue) Len() int           { return len(q) }
+func (q commitQueue) Less(i, j int) bool { return q[i].Committer.When.After(q[j].Committer.When) }
+func (q commitQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *commitQueue) Push(x any)        { *q = append(*q, x.(*object.Commit)) }
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	commit := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return commit
+}
+
+// errStopWalk ends a walk early without an error.
+var errStopWalk = errors.New("stop walk")
+
+// walk visits the commits reachable from starts once each, nearest first. Parents
+// missing from a shallow clone are treated as the end of history.
+func walk(ctx context.Context, repo *git.Repository, starts []plumbing.Hash, visit func(*object.Commit) error) error {
+	seen := map[plumbing.Hash]bool{}
+	queue := append([]plumbing.Hash(nil), starts...)
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hash := queue[0]
+		queue = queue[1:]
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+
+		commit, err := repo.CommitObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read commit %s: %w", hash, err)
+		}
+
+		switch err := visit(commit); err {
+		case nil:
+			queue = append(queue, commit.ParentHashes...)
+		case errStopWalk:
+			return nil
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// withoutCredentials drops the user info from a remote URL. CI checkouts embed a
+// job token in origin (https://gitlab-ci-token:<token>@gitlab.example.com/...),
+// which would otherwise end up in the job log. scp-style remotes such as
+// git@github.com:org/repo.git aren't URLs and carry no secret, so they're kept.
+func withoutCredentials(remote string) string {
+	u, err := url.Parse(remote)
+	if err != nil || u.Scheme == "" || u.User == nil {
+		return remote
+	}
+	u.User = nil
+	return u.String()
+}
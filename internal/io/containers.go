@@ -0,0 +1,64 @@
+package io
+
+import (
+	"fmt"
+	"strings"
+
+	"boxed/internal/box"
+	"boxed/internal/rules"
+)
+
+// Box shows one row per container or pod with its state colored by status. Only
+// unhealthy entries affect the box type: containers that exited cleanly and completed
+// pods are informational and don't turn a healthy box into an info box. An empty
+// title defaults to "Containers" or "Pods".
+func (r *ContainerReport) Box(title string) *box.Box {
+	if title == "" {
+		title = "Containers"
+		if r.Kind == "pods" {
+			title = "Pods"
+		}
+	}
+	b := &box.Box{Type: box.Success, Title: title}
+
+	// Prefix names with the namespace only when they'd otherwise be ambiguous,
+	// e.g. for `kubectl get pods -A`.
+	namespaces := map[string]bool{}
+	for _, entry := range r.Entries {
+		namespaces[entry.Namespace] = true
+	}
+
+	healthy, restarts := 0, 0
+	for _, entry := range r.Entries {
+		if entry.Status == box.Success || entry.Status == box.Info {
+			healthy++
+		} else {
+			b.Type = rules.Worst(b.Type, entry.Status)
+		}
+		restarts += entry.Restarts
+
+		name := entry.Name
+		if len(namespaces) > 1 && entry.Namespace != "" {
+			name = entry.Namespace + "/" + name
+		}
+		b.KVPairs = append(b.KVPairs, box.KV{Key: name, Value: entry.describe(), Status: entry.Status})
+	}
+
+	b.Subtitle = fmt.Sprintf("%d/%d healthy", healthy, len(r.Entries))
+	if restarts > 0 {
+		b.Footer = pluralize(restarts, "restart") + " in total"
+	}
+	return b
+}
+
+// describe joins the state, ready count and restarts, e.g. "Running • 1/2 ready • 3 restarts".
+func (s ContainerStatus) describe() string {
+	parts := []string{s.State}
+	if s.Ready != "" {
+		parts = append(parts, s.Ready+" ready")
+	}
+	if s.Restarts > 0 {
+		parts = append(parts, pluralize(s.Restarts, "restart"))
+	}
+	return strings.Join(parts, " • ")
+}
@@ -0,0 +1,329 @@
+package io
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"boxed/internal/box"
+)
+
+// ContainerStatus is one container or pod as shown in a status box.
+type ContainerStatus struct {
+	Name      string
+	Namespace string
+	// State is what `docker ps` or `kubectl get pods` would show: "running
+	// (healthy)", "exited (1)", "CrashLoopBackOff".
+	State string
+	// Ready is the "ready/total" container count of a pod; empty for Docker.
+	Ready    string
+	Restarts int
+	Status   box.BoxType
+}
+
+// ContainerReport is the result of reading Docker or Kubernetes JSON.
+type ContainerReport struct {
+	// Kind is "containers" for Docker input and "pods" for Kubernetes input.
+	Kind    string
+	Entries []ContainerStatus
+}
+
+// dockerContainer covers both Docker formats: `docker ps --format '{{json .}}'` (and
+// `docker compose ps --format json`) has string fields, `docker inspect` nests State
+// in an object. State is kept raw until we know which one we have.
+type dockerContainer struct {
+	// docker ps
+	Names  string `json:"Names"`
+	Status string `json:"Status"`
+	Health string `json:"Health"`
+	// docker compose ps and docker inspect
+	Name string `json:"Name"`
+	// docker inspect
+	RestartCount int `json:"RestartCount"`
+
+	State json.RawMessage `json:"State"`
+}
+
+type dockerInspectState struct {
+	Status   string `json:"Status"`
+	ExitCode int    `json:"ExitCode"`
+	Health   *struct {
+		Status string `json:"Status"`
+	} `json:"Health"`
+}
+
+type kubernetesObject struct {
+	Kind     string             `json:"kind"`
+	Items    []kubernetesObject `json:"items"`
+	Metadata struct {
+		Name              string  `json:"name"`
+		Namespace         string  `json:"namespace"`
+		DeletionTimestamp *string `json:"deletionTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		Containers []json.RawMessage `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		Phase             string                `json:"phase"`
+		Reason            string                `json:"reason"`
+		ContainerStatuses []kubernetesContainer `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+type kubernetesContainer struct {
+	Ready        bool `json:"ready"`
+	RestartCount int  `json:"restartCount"`
+	State        struct {
+		Waiting *struct {
+			Reason string `json:"reason"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason   string `json:"reason"`
+			ExitCode int    `json:"exitCode"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
+// failingWaitReasons are pod waiting reasons that won't resolve by themselves, as
+// opposed to ContainerCreating or PodInitializing.
+var failingWaitReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// exitCodePattern finds the exit code in `docker ps` statuses like "Exited (137) 2 hours ago".
+var exitCodePattern = regexp.MustCompile(`^\w+ \((\d+)\)`)
+
+// ContainersReader parses container status JSON captured from the Docker and
+// Kubernetes CLIs, so status boxes don't depend on scraping their text columns.
+type ContainersReader struct {
+	reader io.Reader
+	name   string
+}
+
+// NewContainersReader creates a reader for `docker ps --format '{{json .}}'`,
+// `docker inspect` or `kubectl get pods -o json` output. name identifies the input
+// in errors.
+func NewContainersReader(r io.Reader, name string) *ContainersReader {
+	return &ContainersReader{reader: r, name: name}
+}
+
+// ReadContainers detects the input format and returns entries in input order. Empty
+// input is what `docker ps` prints when nothing runs, so it's an empty report of
+// containers rather than an error.
+func (c *ContainersReader) ReadContainers() (*ContainerReport, error) {
+	data, err := io.ReadAll(c.reader)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read: %w", c.name, err)
+	}
+
+	// docker ps prints one object per line and docker inspect prints an array, so
+	// decode a stream of values and flatten arrays.
+	var objects []json.RawMessage
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: invalid JSON: %w", c.name, err)
+		}
+
+		if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+			var values []json.RawMessage
+			if err := json.Unmarshal(value, &values); err != nil {
+				return nil, fmt.Errorf("%s: invalid JSON: %w", c.name, err)
+			}
+			objects = append(objects, values...)
+		} else {
+			objects = append(objects, value)
+		}
+	}
+	if len(objects) == 0 {
+		return &ContainerReport{Kind: "containers"}, nil
+	}
+
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(objects[0], &probe); err != nil {
+		return nil, fmt.Errorf("%s: expected JSON objects: %w", c.name, err)
+	}
+	if probe.Kind != "" {
+		return c.readKubernetes(objects)
+	}
+	return c.readDocker(objects)
+}
+
+func (c *ContainersReader) readDocker(objects []json.RawMessage) (*ContainerReport, error) {
+	report := &ContainerReport{Kind: "containers"}
+	for _, object := range objects {
+		var container dockerContainer
+		if err := json.Unmarshal(object, &container); err != nil {
+			return nil, fmt.Errorf("%s: invalid container: %w", c.name, err)
+		}
+		if container.State == nil && container.Status == "" {
+			return nil, fmt.Errorf("%s: not docker ps, docker inspect or kubectl JSON", c.name)
+		}
+		report.Entries = append(report.Entries, dockerStatus(container))
+	}
+	return report, nil
+}
+
+func dockerStatus(container dockerContainer) ContainerStatus {
+	name := container.Names
+	if name == "" {
+		name = strings.TrimPrefix(container.Name, "/")
+	}
+	entry := ContainerStatus{Name: name, Restarts: container.RestartCount}
+
+	var state, health string
+	var exitCode int
+	var inspect dockerInspectState
+	if json.Unmarshal(container.State, &inspect) == nil && inspect.Status != "" {
+		state, exitCode = inspect.Status, inspect.ExitCode
+		if inspect.Health != nil {
+			health = inspect.Health.Status
+		}
+	} else {
+		// docker ps: the State column exists since Docker 20.10, the Status text
+		// ("Up 2 hours (healthy)", "Exited (1) 3 minutes ago") always.
+		json.Unmarshal(container.State, &state)
+		status := container.Status
+		if state == "" {
+			state, _, _ = strings.Cut(strings.ToLower(status), " ")
+			if state == "up" {
+				state = "running"
+			}
+		}
+		if match := exitCodePattern.FindStringSubmatch(status); match != nil {
+			exitCode, _ = strconv.Atoi(match[1])
+		}
+		health = container.Health
+		switch {
+		case health != "":
+		case strings.Contains(status, "(unhealthy)"):
+			health = "unhealthy"
+		case strings.Contains(status, "(healthy)"):
+			health = "healthy"
+		case strings.Contains(status, "(health: starting)"):
+			health = "starting"
+		}
+	}
+
+	entry.State = state
+	switch {
+	case state == "exited" || state == "restarting":
+		entry.State = fmt.Sprintf("%s (%d)", state, exitCode)
+	case health != "":
+		entry.State = fmt.Sprintf("%s (%s)", state, health)
+	}
+	entry.Status = dockerStateStatus(state, health, exitCode)
+	return entry
+}
+
+// dockerStateStatus treats containers that exited cleanly as finished one-off jobs
+// rather than failures.
+func dockerStateStatus(state, health string, exitCode int) box.BoxType {
+	if health == "unhealthy" {
+		return box.Error
+	}
+	switch state {
+	case "running":
+		if health == "starting" {
+			return box.Pending
+		}
+		return box.Success
+	case "exited":
+		if exitCode == 0 {
+			return box.Info
+		}
+		return box.Error
+	case "restarting", "dead":
+		return box.Error
+	case "created", "removing":
+		return box.Pending
+	}
+	return box.Warning
+}
+
+func (c *ContainersReader) readKubernetes(objects []json.RawMessage) (*ContainerReport, error) {
+	report := &ContainerReport{Kind: "pods"}
+	for _, object := range objects {
+		var obj kubernetesObject
+		if err := json.Unmarshal(object, &obj); err != nil {
+			return nil, fmt.Errorf("%s: invalid Kubernetes object: %w", c.name, err)
+		}
+
+		pods := obj.Items
+		if obj.Kind == "Pod" {
+			pods = []kubernetesObject{obj}
+		}
+		for _, pod := range pods {
+			if pod.Kind != "" && pod.Kind != "Pod" {
+				return nil, fmt.Errorf("%s: unsupported kind %q, expected pods", c.name, pod.Kind)
+			}
+			report.Entries = append(report.Entries, podStatus(pod))
+		}
+	}
+	return report, nil
+}
+
+// podStatus derives the state shown in kubectl's STATUS column: a container's
+// waiting or termination reason is more telling than the pod phase.
+func podStatus(pod kubernetesObject) ContainerStatus {
+	entry := ContainerStatus{
+		Name:      pod.Metadata.Name,
+		Namespace: pod.Metadata.Namespace,
+		State:     pod.Status.Phase,
+	}
+	if pod.Status.Reason != "" {
+		entry.State = pod.Status.Reason
+	}
+
+	ready, failing := 0, false
+	for _, container := range pod.Status.ContainerStatuses {
+		entry.Restarts += container.RestartCount
+		if container.Ready {
+			ready++
+		}
+		switch state := container.State; {
+		case state.Waiting != nil && state.Waiting.Reason != "":
+			entry.State = state.Waiting.Reason
+			failing = failing || failingWaitReasons[state.Waiting.Reason]
+		case state.Terminated != nil && state.Terminated.Reason != "" && pod.Status.Phase != "Running":
+			entry.State = state.Terminated.Reason
+		}
+	}
+	total := max(len(pod.Spec.Containers), len(pod.Status.ContainerStatuses))
+	if total > 0 {
+		entry.Ready = fmt.Sprintf("%d/%d", ready, total)
+	}
+
+	switch {
+	case pod.Metadata.DeletionTimestamp != nil:
+		entry.State = "Terminating"
+		entry.Status = box.Pending
+	case failing || pod.Status.Phase == "Failed":
+		entry.Status = box.Error
+	case pod.Status.Phase == "Succeeded":
+		entry.Status = box.Info
+	case pod.Status.Phase == "Pending":
+		entry.Status = box.Pending
+	case pod.Status.Phase == "Running" && ready == total:
+		entry.Status = box.Success
+	default:
+		entry.Status = box.Warning
+	}
+	return entry
+}
@@ -0,0 +1,153 @@
+package io
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainersReaderFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		kind    string
+		want    []ContainerStatus
+	}{
+		{
+			fixture: "docker_ps.jsonl",
+			kind:    "containers",
+			want: []ContainerStatus{
+				{Name: "db", State: "running (healthy)", Status: box.Success},
+				{Name: "api", State: "running (unhealthy)", Status: box.Error},
+				{Name: "worker", State: "restarting (1)", Status: box.Error},
+				{Name: "migrate", State: "exited (0)", Status: box.Info},
+			},
+		},
+		{
+			fixture: "docker_inspect.json",
+			kind:    "containers",
+			want: []ContainerStatus{
+				{Name: "db", State: "running (healthy)", Status: box.Success},
+				{Name: "cache", State: "exited (137)", Restarts: 3, Status: box.Error},
+			},
+		},
+		{
+			fixture: "kubectl_pods.json",
+			kind:    "pods",
+			want: []ContainerStatus{
+				{Name: "api-7d9f8b6c5-x2k4p", Namespace: "prod", State: "Running", Ready: "2/2", Restarts: 1, Status: box.Success},
+				{Name: "worker-5c8d7f9b4-q7r2s", Namespace: "prod", State: "CrashLoopBackOff", Ready: "0/1", Restarts: 12, Status: box.Error},
+				{Name: "migrate-28f4k", Namespace: "jobs", State: "Completed", Ready: "0/1", Status: box.Info},
+				{Name: "api-7d9f8b6c5-m9n3b", Namespace: "prod", State: "ContainerCreating", Ready: "0/2", Status: box.Pending},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("testdata", tt.fixture))
+			require.NoError(t, err)
+			defer file.Close()
+
+			report, err := NewContainersReader(file, tt.fixture).ReadContainers()
+			require.NoError(t, err)
+			assert.Equal(t, tt.kind, report.Kind)
+			assert.Equal(t, tt.want, report.Entries)
+		})
+	}
+}
+
+func TestContainersReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []ContainerStatus
+		wantErr string
+	}{
+		{
+			name:  "docker ps without State column",
+			input: `{"Names":"web","Status":"Exited (2) 5 minutes ago"}`,
+			want:  []ContainerStatus{{Name: "web", State: "exited (2)", Status: box.Error}},
+		},
+		{
+			name:  "docker ps health starting",
+			input: `{"Names":"web","State":"running","Status":"Up 3 seconds (health: starting)"}`,
+			want:  []ContainerStatus{{Name: "web", State: "running (starting)", Status: box.Pending}},
+		},
+		{
+			name:  "docker compose ps array",
+			input: `[{"Name":"app-web-1","State":"paused","Health":"","Status":"Up 1 minute (Paused)"}]`,
+			want:  []ContainerStatus{{Name: "app-web-1", State: "paused", Status: box.Warning}},
+		},
+		{
+			name:  "single pod terminating",
+			input: `{"kind":"Pod","metadata":{"name":"api-1","deletionTimestamp":"2025-01-14T10:00:00Z"},"status":{"phase":"Running"}}`,
+			want:  []ContainerStatus{{Name: "api-1", State: "Terminating", Status: box.Pending}},
+		},
+		{
+			name:  "evicted pod",
+			input: `{"kind":"PodList","items":[{"metadata":{"name":"api-2"},"status":{"phase":"Failed","reason":"Evicted"}}]}`,
+			want:  []ContainerStatus{{Name: "api-2", State: "Evicted", Status: box.Error}},
+		},
+		{
+			name:  "running pod not ready",
+			input: `{"kind":"Pod","metadata":{"name":"api-3"},"status":{"phase":"Running","containerStatuses":[{"ready":false,"state":{"running":{}}}]}}`,
+			want:  []ContainerStatus{{Name: "api-3", State: "Running", Ready: "0/1", Status: box.Warning}},
+		},
+		{
+			name:    "other kubernetes kinds",
+			input:   `{"kind":"List","items":[{"kind":"Deployment","metadata":{"name":"api"}}]}`,
+			wantErr: `unsupported kind "Deployment"`,
+		},
+		{
+			name:    "unrelated JSON",
+			input:   `{"name":"boxed"}`,
+			wantErr: "not docker ps, docker inspect or kubectl JSON",
+		},
+		{
+			name:    "invalid JSON",
+			input:   `{"Names":`,
+			wantErr: "invalid JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewContainersReader(strings.NewReader(tt.input), "stdin").ReadContainers()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Contains(t, err.Error(), "stdin")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, report.Entries)
+		})
+	}
+}
+
+func TestContainersReaderEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantKind string
+	}{
+		{name: "docker ps with nothing running", input: "", wantKind: "containers"},
+		{name: "docker inspect of nothing", input: "[]\n", wantKind: "containers"},
+		{name: "empty pod list", input: `{"kind":"PodList","items":[]}`, wantKind: "pods"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewContainersReader(strings.NewReader(tt.input), "stdin").ReadContainers()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKind, report.Kind)
+			assert.Empty(t, report.Entries)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package io
+
+import (
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerReportBox(t *testing.T) {
+	tests := []struct {
+		name   string
+		report ContainerReport
+		title  string
+		want   *box.Box
+	}{
+		{
+			name: "healthy containers with a finished job",
+			report: ContainerReport{Kind: "containers", Entries: []ContainerStatus{
+				{Name: "db", State: "running (healthy)", Status: box.Success},
+				{Name: "migrate", State: "exited (0)", Status: box.Info},
+			}},
+			want: &box.Box{
+				Type:     box.Success,
+				Title:    "Containers",
+				Subtitle: "2/2 healthy",
+				KVPairs: []box.KV{
+					{Key: "db", Value: "running (healthy)", Status: box.Success},
+					{Key: "migrate", Value: "exited (0)", Status: box.Info},
+				},
+			},
+		},
+		{
+			name: "pods across namespaces",
+			report: ContainerReport{Kind: "pods", Entries: []ContainerStatus{
+				{Name: "api", Namespace: "prod", State: "Running", Ready: "1/2", Restarts: 1, Status: box.Warning},
+				{Name: "worker", Namespace: "jobs", State: "CrashLoopBackOff", Ready: "0/1", Restarts: 12, Status: box.Error},
+				{Name: "web", Namespace: "prod", State: "ContainerCreating", Ready: "0/1", Status: box.Pending},
+			}},
+			title: "Cluster",
+			want: &box.Box{
+				Type:     box.Error,
+				Title:    "Cluster",
+				Subtitle: "0/3 healthy",
+				KVPairs: []box.KV{
+					{Key: "prod/api", Value: "Running • 1/2 ready • 1 restart", Status: box.Warning},
+					{Key: "jobs/worker", Value: "CrashLoopBackOff • 0/1 ready • 12 restarts", Status: box.Error},
+					{Key: "prod/web", Value: "ContainerCreating • 0/1 ready", Status: box.Pending},
+				},
+				Footer: "13 restarts in total",
+			},
+		},
+		{
+			name:   "nothing running",
+			report: ContainerReport{Kind: "containers"},
+			want:   &box.Box{Type: box.Success, Title: "Containers", Subtitle: "0/0 healthy"},
+		},
+		{
+			name: "pending pods in one namespace",
+			report: ContainerReport{Kind: "pods", Entries: []ContainerStatus{
+				{Name: "web", Namespace: "prod", State: "ContainerCreating", Ready: "0/1", Status: box.Pending},
+				{Name: "api", Namespace: "prod", State: "Running", Ready: "1/1", Status: box.Success},
+			}},
+			want: &box.Box{
+				Type:     box.Pending,
+				Title:    "Pods",
+				Subtitle: "1/2 healthy",
+				KVPairs: []box.KV{
+					{Key: "web", Value: "ContainerCreating • 0/1 ready", Status: box.Pending},
+					{Key: "api", Value: "Running • 1/1 ready", Status: box.Success},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.report.Box(tt.title))
+		})
+	}
+}
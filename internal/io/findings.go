@@ -0,0 +1,159 @@
+package io
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"boxed/internal/box"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxTopEntries is how many rules and files are named; the long tail is counted.
+const maxTopEntries = 5
+
+// severityOrder lists severities most severe first, with the box type, row label
+// and table marker for each.
+var severityOrder = []struct {
+	severity string
+	boxType  box.BoxType
+	label    string
+	marker   string
+}{
+	{SeverityError, box.Error, "Errors", "✖"},
+	{SeverityWarning, box.Warning, "Warnings", "⚠"},
+	{SeverityNote, box.Info, "Notes", "ℹ"},
+}
+
+// FindingsBox summarizes findings from one or more reports: a row per severity, the
+// rules and files with the most findings, and a table of the first limit findings,
+// most severe first (no table when limit is zero). The box type follows the highest
+// severity; no findings is a success.
+func FindingsBox(findings []Finding, title string, limit int) *box.Box {
+	b := &box.Box{Type: box.Success, Title: title}
+
+	files := map[string]bool{}
+	for _, finding := range findings {
+		files[finding.File] = true
+	}
+	b.Subtitle = "no findings"
+	if len(findings) > 0 {
+		b.Subtitle = fmt.Sprintf("%s in %s", pluralize(len(findings), "finding"), pluralize(len(files), "file"))
+	}
+
+	for _, level := range severityOrder {
+		count := countWhere(findings, func(f Finding) bool { return f.Severity == level.severity })
+		if count == 0 {
+			continue
+		}
+		if b.Type == box.Success {
+			b.Type = level.boxType
+		}
+		b.KVPairs = append(b.KVPairs, box.KV{Key: level.label, Value: fmt.Sprint(count), Status: level.boxType})
+	}
+
+	if len(findings) > 0 {
+		b.KVPairs = append(b.KVPairs,
+			box.KV{Key: "Rules", Value: topCounts(findings, func(f Finding) string { return f.Rule })},
+			box.KV{Key: "Files", Value: topCounts(findings, func(f Finding) string { return f.File })},
+		)
+	}
+	if len(findings) > 0 && limit > 0 {
+		b.Body = findingsTable(findings, limit)
+	}
+
+	var tools []string
+	for _, finding := range findings {
+		if finding.Tool != "" && !slices.Contains(tools, finding.Tool) {
+			tools = append(tools, finding.Tool)
+		}
+	}
+	b.Footer = strings.Join(tools, " • ")
+
+	return b
+}
+
+func countWhere(findings []Finding, match func(Finding) bool) int {
+	count := 0
+	for _, finding := range findings {
+		if match(finding) {
+			count++
+		}
+	}
+	return count
+}
+
+// topCounts names the most frequent values of key with their counts, e.g.
+// "errcheck (5), govet (3), … 4 more".
+func topCounts(findings []Finding, key func(Finding) string) string {
+	counts := map[string]int{}
+	var values []string
+	for _, finding := range findings {
+		value := key(finding)
+		if value == "" {
+			value = "(none)"
+		}
+		if counts[value] == 0 {
+			values = append(values, value)
+		}
+		counts[value]++
+	}
+	// Stable so ties keep report order, which keeps the output deterministic.
+	slices.SortStableFunc(values, func(a, b string) int { return cmp.Compare(counts[b], counts[a]) })
+
+	var parts []string
+	for i, value := range values {
+		if i == maxTopEntries {
+			parts = append(parts, fmt.Sprintf("… %d more", len(values)-maxTopEntries))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", value, counts[value]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func findingsTable(findings []Finding, limit int) string {
+	sorted := slices.Clone(findings)
+	slices.SortStableFunc(sorted, func(a, b Finding) int {
+		return cmp.Compare(severityRank(a.Severity), severityRank(b.Severity))
+	})
+
+	rows := [][]string{{"Location", "Rule", "Message"}}
+	for i, finding := range sorted {
+		if i == limit {
+			rows = append(rows, []string{fmt.Sprintf("… and %d more", len(sorted)-limit), "", ""})
+			break
+		}
+		location := finding.File
+		if finding.Line > 0 {
+			location = fmt.Sprintf("%s:%d", finding.File, finding.Line)
+		}
+		message, _, _ := strings.Cut(strings.TrimSpace(finding.Message), "\n")
+		rows = append(rows, []string{
+			severityMarker(finding.Severity) + " " + location,
+			finding.Rule,
+			ansi.Truncate(message, maxMessageWidth, "…"),
+		})
+	}
+	return formatTable(rows)
+}
+
+func severityRank(severity string) int {
+	for i, level := range severityOrder {
+		if level.severity == severity {
+			return i
+		}
+	}
+	return len(severityOrder)
+}
+
+func severityMarker(severity string) string {
+	for _, level := range severityOrder {
+		if level.severity == severity {
+			return level.marker
+		}
+	}
+	return "•"
+}
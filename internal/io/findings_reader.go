@@ -0,0 +1,184 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Severity levels of static-analysis findings, from SARIF's result levels. Other
+// tools' severities are mapped onto these.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+)
+
+// Finding is one static-analysis result.
+type Finding struct {
+	Tool     string
+	Rule     string
+	Severity string
+	Message  string
+	File     string
+	Line     int
+}
+
+// findingsDocument covers both supported formats; which fields are present tells
+// them apart, so callers don't have to say which tool produced a file.
+type findingsDocument struct {
+	// SARIF 2.1.0
+	Runs []sarifRun `json:"runs"`
+	// golangci-lint --out-format json
+	Issues *[]golangciIssue `json:"Issues"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name  string      `json:"name"`
+			Rules []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifRule struct {
+	ID                   string `json:"id"`
+	DefaultConfiguration struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID    string `json:"ruleId"`
+	RuleIndex *int   `json:"ruleIndex"`
+	Level     string `json:"level"`
+	Kind      string `json:"kind"`
+	Message   struct {
+		Text string `json:"text"`
+	} `json:"message"`
+	Locations []struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+			Region struct {
+				StartLine int `json:"startLine"`
+			} `json:"region"`
+		} `json:"physicalLocation"`
+	} `json:"locations"`
+}
+
+type golangciIssue struct {
+	FromLinter string `json:"FromLinter"`
+	Text       string `json:"Text"`
+	Severity   string `json:"Severity"`
+	Pos        struct {
+		Filename string `json:"Filename"`
+		Line     int    `json:"Line"`
+	} `json:"Pos"`
+}
+
+// FindingsReader parses static-analysis reports: SARIF (CodeQL, gosec, semgrep,
+// staticcheck...) and golangci-lint's JSON output.
+type FindingsReader struct {
+	reader io.Reader
+	name   string
+}
+
+// NewFindingsReader creates a reader for a SARIF or golangci-lint JSON report. name
+// identifies the report in errors.
+func NewFindingsReader(r io.Reader, name string) *FindingsReader {
+	return &FindingsReader{reader: r, name: name}
+}
+
+// ReadFindings detects the report format and returns its findings in report order.
+func (f *FindingsReader) ReadFindings() ([]Finding, error) {
+	var doc findingsDocument
+	if err := json.NewDecoder(f.reader).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%s: invalid JSON: %w", f.name, err)
+	}
+
+	switch {
+	case doc.Issues != nil:
+		return golangciFindings(*doc.Issues), nil
+	case doc.Runs != nil:
+		return sarifFindings(doc.Runs), nil
+	}
+	return nil, fmt.Errorf("%s: not a SARIF or golangci-lint JSON report", f.name)
+}
+
+func sarifFindings(runs []sarifRun) []Finding {
+	var findings []Finding
+	for _, run := range runs {
+		rules := run.Tool.Driver.Rules
+		levels := make(map[string]string, len(rules))
+		for _, rule := range rules {
+			levels[rule.ID] = rule.DefaultConfiguration.Level
+		}
+
+		for _, result := range run.Results {
+			// Results may also record checks that passed or didn't apply.
+			if result.Kind != "" && result.Kind != "fail" {
+				continue
+			}
+
+			ruleID := result.RuleID
+			if ruleID == "" && result.RuleIndex != nil && *result.RuleIndex < len(rules) {
+				ruleID = rules[*result.RuleIndex].ID
+			}
+			// SARIF's level falls back to the rule's default, then to warning.
+			level := result.Level
+			if level == "" {
+				level = levels[ruleID]
+			}
+
+			finding := Finding{
+				Tool:     run.Tool.Driver.Name,
+				Rule:     ruleID,
+				Severity: normalizeSeverity(level, SeverityWarning),
+				Message:  result.Message.Text,
+			}
+			if len(result.Locations) > 0 {
+				location := result.Locations[0].PhysicalLocation
+				finding.File = strings.TrimPrefix(location.ArtifactLocation.URI, "file://")
+				finding.Line = location.Region.StartLine
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func golangciFindings(issues []golangciIssue) []Finding {
+	findings := make([]Finding, 0, len(issues))
+	for _, issue := range issues {
+		findings = append(findings, Finding{
+			Tool: "golangci-lint",
+			Rule: issue.FromLinter,
+			// golangci-lint fails the build on any issue unless severities are
+			// configured, so an unset severity is an error.
+			Severity: normalizeSeverity(issue.Severity, SeverityError),
+			Message:  issue.Text,
+			File:     issue.Pos.Filename,
+			Line:     issue.Pos.Line,
+		})
+	}
+	return findings
+}
+
+// normalizeSeverity maps the severity names tools use (including code-climate style
+// blocker/major/minor) onto error, warning and note.
+func normalizeSeverity(severity, fallback string) string {
+	switch strings.ToLower(severity) {
+	case "error", "critical", "blocker", "high", "major":
+		return SeverityError
+	case "warning", "warn", "medium", "minor":
+		return SeverityWarning
+	case "note", "info", "low", "none", "hint":
+		return SeverityNote
+	}
+	return fallback
+}
@@ -0,0 +1,103 @@
+package io
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sarifReport = `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [{
+    "tool": {"driver": {"name": "gosec", "rules": [
+      {"id": "G101", "defaultConfiguration": {"level": "error"}},
+      {"id": "G104"}
+    ]}},
+    "results": [
+      {"ruleId": "G101", "message": {"text": "Potential hardcoded credentials"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "config/secrets.go"}, "region": {"startLine": 12}}}]},
+      {"ruleIndex": 1, "message": {"text": "Errors unhandled"},
+       "locations": [{"physicalLocation": {"artifactLocation": {"uri": "file:///src/app/main.go"}, "region": {"startLine": 40}}}]},
+      {"ruleId": "G104", "level": "note", "message": {"text": "Informational"}},
+      {"ruleId": "G101", "kind": "pass", "message": {"text": "Checked"}}
+    ]
+  }]
+}`
+
+const golangciReport = `{
+  "Issues": [
+    {"FromLinter": "errcheck", "Text": "Error return value is not checked", "Severity": "", "Pos": {"Filename": "cmd/root.go", "Line": 88, "Column": 3}},
+    {"FromLinter": "revive", "Text": "exported function should have comment", "Severity": "warning", "Pos": {"Filename": "internal/x.go", "Line": 5}}
+  ],
+  "Report": {"Linters": []}
+}`
+
+func TestFindingsReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Finding
+		wantErr string
+	}{
+		{
+			name:  "SARIF",
+			input: sarifReport,
+			want: []Finding{
+				{Tool: "gosec", Rule: "G101", Severity: SeverityError, Message: "Potential hardcoded credentials", File: "config/secrets.go", Line: 12},
+				{Tool: "gosec", Rule: "G104", Severity: SeverityWarning, Message: "Errors unhandled", File: "/src/app/main.go", Line: 40},
+				{Tool: "gosec", Rule: "G104", Severity: SeverityNote, Message: "Informational"},
+			},
+		},
+		{
+			name:  "golangci-lint",
+			input: golangciReport,
+			want: []Finding{
+				{Tool: "golangci-lint", Rule: "errcheck", Severity: SeverityError, Message: "Error return value is not checked", File: "cmd/root.go", Line: 88},
+				{Tool: "golangci-lint", Rule: "revive", Severity: SeverityWarning, Message: "exported function should have comment", File: "internal/x.go", Line: 5},
+			},
+		},
+		{name: "clean golangci-lint run", input: `{"Issues": []}`, want: []Finding{}},
+		{name: "clean SARIF run", input: `{"runs": [{"tool": {"driver": {"name": "codeql"}}, "results": []}]}`},
+		{name: "unknown format", input: `{"findings": []}`, wantErr: "report.json: not a SARIF or golangci-lint JSON report"},
+		{name: "invalid JSON", input: `{`, wantErr: "report.json: invalid JSON: unexpected EOF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := NewFindingsReader(strings.NewReader(tt.input), "report.json").ReadFindings()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, findings)
+		})
+	}
+}
+
+func TestNormalizeSeverity(t *testing.T) {
+	tests := []struct {
+		severity string
+		want     string
+	}{
+		{"error", SeverityError},
+		{"MAJOR", SeverityError},
+		{"critical", SeverityError},
+		{"warning", SeverityWarning},
+		{"minor", SeverityWarning},
+		{"note", SeverityNote},
+		{"info", SeverityNote},
+		{"none", SeverityNote},
+		{"", "fallback"},
+		{"weird", "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeSeverity(tt.severity, "fallback"))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package io
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindingsBox(t *testing.T) {
+	findings := []Finding{
+		{Tool: "golangci-lint", Rule: "revive", Severity: SeverityWarning, Message: "exported function should have comment", File: "internal/x.go", Line: 5},
+		{Tool: "golangci-lint", Rule: "errcheck", Severity: SeverityError, Message: "Error return value is not checked", File: "cmd/root.go", Line: 88},
+		{Tool: "gosec", Rule: "G104", Severity: SeverityNote, Message: "Informational\nsecond line", File: "cmd/root.go"},
+		{Tool: "golangci-lint", Rule: "revive", Severity: SeverityWarning, Message: "var should be camelCase", File: "cmd/root.go", Line: 12},
+	}
+
+	b := FindingsBox(findings, "Lint", 10)
+
+	assert.Equal(t, box.Error, b.Type, "the highest severity decides the type")
+	assert.Equal(t, "Lint", b.Title)
+	assert.Equal(t, "4 findings in 2 files", b.Subtitle)
+	assert.Equal(t, "golangci-lint • gosec", b.Footer)
+	assert.Equal(t, []box.KV{
+		{Key: "Errors", Value: "1", Status: box.Error},
+		{Key: "Warnings", Value: "2", Status: box.Warning},
+		{Key: "Notes", Value: "1", Status: box.Info},
+		{Key: "Rules", Value: "revive (2), errcheck (1), G104 (1)"},
+		{Key: "Files", Value: "cmd/root.go (3), internal/x.go (1)"},
+	}, b.KVPairs)
+	assert.Equal(t, `Location           Rule      Message
+✖ cmd/root.go:88   errcheck  Error return value is not checked
+⚠ internal/x.go:5  revive    exported function should have comment
+⚠ cmd/root.go:12   revive    var should be camelCase
+ℹ cmd/root.go      G104      Informational`, b.Body)
+}
+
+func TestFindingsBoxTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []Finding
+		wantType box.BoxType
+	}{
+		{name: "no findings", findings: nil, wantType: box.Success},
+		{name: "notes only", findings: []Finding{{Severity: SeverityNote}}, wantType: box.Info},
+		{name: "warnings", findings: []Finding{{Severity: SeverityNote}, {Severity: SeverityWarning}}, wantType: box.Warning},
+		{name: "errors", findings: []Finding{{Severity: SeverityWarning}, {Severity: SeverityError}}, wantType: box.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantType, FindingsBox(tt.findings, "Lint", 10).Type)
+		})
+	}
+}
+
+func TestFindingsBoxLimits(t *testing.T) {
+	var findings []Finding
+	for i := 0; i < 8; i++ {
+		findings = append(findings, Finding{Rule: fmt.Sprintf("rule%d", i), Severity: SeverityWarning, File: "a.go", Line: i + 1})
+	}
+
+	b := FindingsBox(findings, "Lint", 3)
+
+	lines := strings.Split(b.Body, "\n")
+	assert.Len(t, lines, 5, "header, three findings and a summary line")
+	assert.Equal(t, "… and 5 more", lines[4])
+	assert.Equal(t, "rule0 (1), rule1 (1), rule2 (1), rule3 (1), rule4 (1), … 3 more", b.KVPairs[1].Value)
+
+	assert.Empty(t, FindingsBox(findings, "Lint", 0).Body)
+}
@@ -0,0 +1,66 @@
+package io
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// maxEventBytes allows long output lines (large diffs, JSON fixtures) without the
+// scanner failing on its 64KB default.
+const maxEventBytes = 1024 * 1024
+
+// GoTestEvent is one event of the `go test -json` (test2json) stream.
+type GoTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+	// ImportPath and FailedBuild come with build failures: build-output events
+	// carry the compiler output for ImportPath, and the package's fail event names
+	// the build that failed.
+	ImportPath  string `json:"ImportPath"`
+	FailedBuild string `json:"FailedBuild"`
+}
+
+// GoTestReader parses a test2json event stream.
+type GoTestReader struct {
+	reader io.Reader
+}
+
+// NewGoTestReader creates a reader for `go test -json` output.
+func NewGoTestReader(r io.Reader) *GoTestReader {
+	return &GoTestReader{reader: r}
+}
+
+// ReadEvents calls fn for each event as it is read, so callers can show progress
+// while tests are still running. Lines that aren't JSON events are skipped: `go test
+// -json 2>&1` mixes in plain stderr output that the events already describe.
+func (g *GoTestReader) ReadEvents(fn func(GoTestEvent)) error {
+	scanner := bufio.NewScanner(g.reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventBytes)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var event GoTestEvent
+		if err := json.Unmarshal(line, &event); err != nil || event.Action == "" {
+			continue
+		}
+		fn(event)
+	}
+	return scanner.Err()
+}
+
+// ReadReport reads the whole stream into a report.
+func (g *GoTestReader) ReadReport() (*GoTestReport, error) {
+	report := NewGoTestReport()
+	if err := g.ReadEvents(report.Add); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
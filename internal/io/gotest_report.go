@@ -0,0 +1,321 @@
+package io
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"boxed/internal/box"
+)
+
+const (
+	// maxFailureLines keeps the box readable; the first lines of a failure usually
+	// name the assertion and location, which is what's needed to start looking.
+	maxFailureLines = 3
+	// maxListedFailures stops a broken build from producing a box hundreds of lines
+	// long; the counts still include every failure.
+	maxListedFailures = 10
+)
+
+// GoTestPackage aggregates the results of one package.
+type GoTestPackage struct {
+	Name    string
+	Passed  int
+	Failed  int
+	Skipped int
+	Elapsed time.Duration
+	// Result is the package's final action (pass, fail or skip); empty while running.
+	Result string
+	// BuildFailed is set when the test binary didn't compile.
+	BuildFailed bool
+}
+
+// GoTestFailure is a failed test, or a package that failed outside any test (a build
+// failure or a panic in TestMain), with the first lines of its output.
+type GoTestFailure struct {
+	Package string
+	Test    string
+	Lines   []string
+}
+
+// GoTestReport aggregates a test2json stream. Counts include subtests, as `go test
+// -v` lists them, but a parent that only failed because a subtest did is neither
+// counted nor listed: its failure adds nothing, and one broken case shouldn't read
+// as "2 failed".
+type GoTestReport struct {
+	Packages []*GoTestPackage
+	Failures []GoTestFailure
+
+	packages    map[string]*GoTestPackage
+	output      map[string][]string
+	buildOutput map[string][]string
+	start, end  time.Time
+}
+
+// NewGoTestReport creates an empty report.
+func NewGoTestReport() *GoTestReport {
+	return &GoTestReport{
+		packages:    map[string]*GoTestPackage{},
+		output:      map[string][]string{},
+		buildOutput: map[string][]string{},
+	}
+}
+
+// Add folds one event into the report.
+func (r *GoTestReport) Add(event GoTestEvent) {
+	if !event.Time.IsZero() {
+		if r.start.IsZero() || event.Time.Before(r.start) {
+			r.start = event.Time
+		}
+		if event.Time.After(r.end) {
+			r.end = event.Time
+		}
+	}
+
+	if event.Action == "build-output" {
+		// The "# package" header repeats what the failure is already listed under.
+		if !strings.HasPrefix(event.Output, "# ") {
+			r.buildOutput[event.ImportPath] = appendOutput(r.buildOutput[event.ImportPath], event.Output)
+		}
+		return
+	}
+	if event.Package == "" {
+		return
+	}
+
+	pkg := r.packages[event.Package]
+	if pkg == nil {
+		pkg = &GoTestPackage{Name: event.Package}
+		r.packages[event.Package] = pkg
+		r.Packages = append(r.Packages, pkg)
+	}
+
+	key := event.Package + "\x00" + event.Test
+	switch event.Action {
+	case "output":
+		r.output[key] = appendOutput(r.output[key], event.Output)
+	case "pass", "fail", "skip":
+		if event.Test == "" {
+			r.finishPackage(pkg, event)
+			return
+		}
+		switch event.Action {
+		case "pass":
+			pkg.Passed++
+		case "skip":
+			pkg.Skipped++
+		case "fail":
+			if !r.hasFailedSubtest(event.Package, event.Test) {
+				pkg.Failed++
+				r.Failures = append(r.Failures, GoTestFailure{Package: event.Package, Test: event.Test, Lines: r.output[key]})
+			}
+		}
+		delete(r.output, key)
+	}
+}
+
+func (r *GoTestReport) finishPackage(pkg *GoTestPackage, event GoTestEvent) {
+	pkg.Result = event.Action
+	pkg.Elapsed = time.Duration(event.Elapsed * float64(time.Second))
+	pkg.BuildFailed = event.FailedBuild != ""
+
+	if event.Action == "fail" && pkg.Failed == 0 {
+		lines := r.output[event.Package+"\x00"]
+		if event.FailedBuild != "" {
+			lines = r.buildOutput[event.FailedBuild]
+		}
+		r.Failures = append(r.Failures, GoTestFailure{Package: event.Package, Lines: lines})
+	}
+	delete(r.output, event.Package+"\x00")
+}
+
+func (r *GoTestReport) hasFailedSubtest(pkg, test string) bool {
+	for _, failure := range r.Failures {
+		if failure.Package == pkg && strings.HasPrefix(failure.Test, test+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendOutput keeps the first meaningful output lines, dropping the framing lines
+// test2json also reports as events (=== RUN, --- FAIL, PASS, ok ...).
+func appendOutput(lines []string, output string) []string {
+	if len(lines) >= maxFailureLines {
+		return lines
+	}
+	line := strings.TrimRight(output, "\r\n \t")
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || trimmed == "PASS" || trimmed == "FAIL" {
+		return lines
+	}
+	for _, prefix := range []string{"=== ", "--- PASS", "--- FAIL", "--- SKIP", "ok  \t", "FAIL\t", "?   \t"} {
+		if strings.HasPrefix(trimmed, prefix) || strings.HasPrefix(line, prefix) {
+			return lines
+		}
+	}
+	return append(lines, line)
+}
+
+// Totals sums test counts over all packages.
+func (r *GoTestReport) Totals() (passed, failed, skipped int) {
+	for _, pkg := range r.Packages {
+		passed += pkg.Passed
+		failed += pkg.Failed
+		skipped += pkg.Skipped
+	}
+	return passed, failed, skipped
+}
+
+// Elapsed is the wall time covered by the stream. Packages run in parallel, so this
+// is shorter than the sum of package times; the sum is the fallback for streams
+// without timestamps.
+func (r *GoTestReport) Elapsed() time.Duration {
+	if !r.start.IsZero() && r.end.After(r.start) {
+		return r.end.Sub(r.start)
+	}
+	var total time.Duration
+	for _, pkg := range r.Packages {
+		total += pkg.Elapsed
+	}
+	return total
+}
+
+// Box summarizes the report. While the stream is still running (done is false) the
+// box is pending and unfinished packages are marked as running; once done, any
+// failure makes it an error, and a package that never reported a result (the
+// stream was cut off) counts as a failure too.
+func (r *GoTestReport) Box(done bool) *box.Box {
+	passed, failed, skipped := r.Totals()
+	b := &box.Box{
+		Title:    "Go Tests",
+		Subtitle: joinCounts(passed, failed, skipped),
+	}
+	if b.Subtitle == "" {
+		b.Subtitle = "no tests"
+	}
+
+	var tested []*GoTestPackage
+	untested := 0
+	for _, pkg := range r.Packages {
+		if pkg.Result == "skip" && pkg.Passed+pkg.Failed+pkg.Skipped == 0 {
+			untested++
+			continue
+		}
+		tested = append(tested, pkg)
+	}
+
+	prefix := commonPackagePrefix(tested)
+	incomplete := false
+	for _, pkg := range tested {
+		kv := box.KV{Key: strings.TrimPrefix(pkg.Name, prefix)}
+		counts := joinCounts(pkg.Passed, pkg.Failed, pkg.Skipped)
+		switch {
+		case pkg.Result == "" && !done:
+			kv.Value, kv.Status = strings.TrimPrefix(counts+" • running", " • "), box.Pending
+		case pkg.Result == "":
+			kv.Value, kv.Status = strings.TrimPrefix(counts+" • no result", " • "), box.Error
+			incomplete = true
+		default:
+			if pkg.BuildFailed {
+				counts = "build failed"
+			} else if counts == "" {
+				counts = pkg.Result
+			}
+			kv.Value = fmt.Sprintf("%s (%s)", counts, formatTestDuration(pkg.Elapsed))
+			kv.Status = box.Success
+			if pkg.Result == "fail" {
+				kv.Status = box.Error
+			}
+		}
+		b.KVPairs = append(b.KVPairs, kv)
+	}
+
+	b.Body = r.failureText(prefix)
+
+	footer := []string{formatTestDuration(r.Elapsed()) + " total"}
+	if untested > 0 {
+		footer = append(footer, fmt.Sprintf("%d %s without tests", untested, pluralNoun(untested, "package")))
+	}
+	b.Footer = strings.Join(footer, " • ")
+
+	switch {
+	case !done:
+		b.Type = box.Pending
+	case failed > 0 || len(r.Failures) > 0 || incomplete:
+		b.Type = box.Error
+	case passed == 0:
+		b.Type = box.Warning
+	default:
+		b.Type = box.Success
+	}
+	return b
+}
+
+// failureText lists failing tests with the first lines of their output.
+func (r *GoTestReport) failureText(prefix string) string {
+	var lines []string
+	for i, failure := range r.Failures {
+		if i == maxListedFailures {
+			lines = append(lines, fmt.Sprintf("… and %d more", len(r.Failures)-maxListedFailures))
+			break
+		}
+		pkg := strings.TrimPrefix(failure.Package, prefix)
+		if failure.Test == "" {
+			lines = append(lines, fmt.Sprintf("✖ %s", pkg))
+		} else {
+			lines = append(lines, fmt.Sprintf("✖ %s (%s)", failure.Test, pkg))
+		}
+		for _, line := range failure.Lines {
+			lines = append(lines, "    "+strings.TrimLeft(line, " \t"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// commonPackagePrefix is the shared directory prefix of the package paths, dropped
+// from the keys so they aren't all "github.com/org/repo/...". A single package keeps
+// its full path.
+func commonPackagePrefix(packages []*GoTestPackage) string {
+	if len(packages) < 2 {
+		return ""
+	}
+	prefix := packages[0].Name
+	for _, pkg := range packages[1:] {
+		for !strings.HasPrefix(pkg.Name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		return prefix[:i+1]
+	}
+	return ""
+}
+
+func joinCounts(passed, failed, skipped int) string {
+	var parts []string
+	if passed > 0 {
+		parts = append(parts, fmt.Sprintf("%d passed", passed))
+	}
+	if failed > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", failed))
+	}
+	if skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", skipped))
+	}
+	return strings.Join(parts, " • ")
+}
+
+func formatTestDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(10 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
+
+func pluralNoun(n int, noun string) string {
+	if n == 1 {
+		return noun
+	}
+	return noun + "s"
+}
@@ -0,0 +1,150 @@
+package io
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goTestStream is trimmed `go test -json ./...` output for a module with a failing
+// subtest, a build failure, a package without tests and a passing package.
+const goTestStream = `{"Time":"2025-10-19T12:00:00.000Z","Action":"start","Package":"example.com/app/bad"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"run","Package":"example.com/app/bad","Test":"TestGood"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestGood","Output":"=== RUN   TestGood\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestGood","Output":"--- PASS: TestGood (0.00s)\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"pass","Package":"example.com/app/bad","Test":"TestGood","Elapsed":0}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"run","Package":"example.com/app/bad","Test":"TestTable"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestTable","Output":"=== RUN   TestTable\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"run","Package":"example.com/app/bad","Test":"TestTable/empty"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestTable/empty","Output":"=== RUN   TestTable/empty\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestTable/empty","Output":"    bad_test.go:5: got \"\", want \"x\"\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestTable/empty","Output":"--- FAIL: TestTable/empty (0.00s)\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"fail","Package":"example.com/app/bad","Test":"TestTable/empty","Elapsed":0}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"run","Package":"example.com/app/bad","Test":"TestTable/fine"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"pass","Package":"example.com/app/bad","Test":"TestTable/fine","Elapsed":0}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Test":"TestTable","Output":"--- FAIL: TestTable (0.00s)\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"fail","Package":"example.com/app/bad","Test":"TestTable","Elapsed":0}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Output":"FAIL\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"output","Package":"example.com/app/bad","Output":"FAIL\texample.com/app/bad\t0.012s\n"}
+{"Time":"2025-10-19T12:00:00.100Z","Action":"fail","Package":"example.com/app/bad","Elapsed":0.012}
+{"ImportPath":"example.com/app/nobuild [example.com/app/nobuild.test]","Action":"build-output","Output":"# example.com/app/nobuild [example.com/app/nobuild.test]\n"}
+{"ImportPath":"example.com/app/nobuild [example.com/app/nobuild.test]","Action":"build-output","Output":"nobuild/x_test.go:3:28: undefined: undefined\n"}
+{"ImportPath":"example.com/app/nobuild [example.com/app/nobuild.test]","Action":"build-fail"}
+# example.com/app/nobuild [example.com/app/nobuild.test]
+{"Time":"2025-10-19T12:00:00.200Z","Action":"output","Package":"example.com/app/nobuild","Output":"FAIL\texample.com/app/nobuild [build failed]\n"}
+{"Time":"2025-10-19T12:00:00.200Z","Action":"fail","Package":"example.com/app/nobuild","Elapsed":0,"FailedBuild":"example.com/app/nobuild [example.com/app/nobuild.test]"}
+{"Time":"2025-10-19T12:00:00.300Z","Action":"output","Package":"example.com/app/none","Output":"?   \texample.com/app/none\t[no test files]\n"}
+{"Time":"2025-10-19T12:00:00.300Z","Action":"skip","Package":"example.com/app/none","Elapsed":0}
+{"Time":"2025-10-19T12:00:01.000Z","Action":"run","Package":"example.com/app/ok","Test":"TestA"}
+{"Time":"2025-10-19T12:00:01.000Z","Action":"pass","Package":"example.com/app/ok","Test":"TestA","Elapsed":0}
+{"Time":"2025-10-19T12:00:01.000Z","Action":"run","Package":"example.com/app/ok","Test":"TestSkip"}
+{"Time":"2025-10-19T12:00:01.000Z","Action":"output","Package":"example.com/app/ok","Test":"TestSkip","Output":"    ok_test.go:4: later\n"}
+{"Time":"2025-10-19T12:00:01.000Z","Action":"skip","Package":"example.com/app/ok","Test":"TestSkip","Elapsed":0}
+{"Time":"2025-10-19T12:00:01.000Z","Action":"output","Package":"example.com/app/ok","Output":"ok  \texample.com/app/ok\t1.234s\n"}
+{"Time":"2025-10-19T12:00:01.500Z","Action":"pass","Package":"example.com/app/ok","Elapsed":1.234}
+`
+
+func TestGoTestReader(t *testing.T) {
+	report, err := NewGoTestReader(strings.NewReader(goTestStream)).ReadReport()
+	require.NoError(t, err)
+
+	require.Len(t, report.Packages, 4)
+	assert.Equal(t, &GoTestPackage{Name: "example.com/app/bad", Passed: 2, Failed: 1, Elapsed: 12 * time.Millisecond, Result: "fail"}, report.Packages[0])
+	assert.Equal(t, &GoTestPackage{Name: "example.com/app/nobuild", Result: "fail", BuildFailed: true}, report.Packages[1])
+	assert.Equal(t, &GoTestPackage{Name: "example.com/app/none", Result: "skip"}, report.Packages[2])
+	assert.Equal(t, &GoTestPackage{Name: "example.com/app/ok", Passed: 1, Skipped: 1, Elapsed: 1234 * time.Millisecond, Result: "pass"}, report.Packages[3])
+
+	assert.Equal(t, []GoTestFailure{
+		{Package: "example.com/app/bad", Test: "TestTable/empty", Lines: []string{`    bad_test.go:5: got "", want "x"`}},
+		{Package: "example.com/app/nobuild", Lines: []string{"nobuild/x_test.go:3:28: undefined: undefined"}},
+	}, report.Failures, "the failing parent test is not listed next to its subtest")
+
+	passed, failed, skipped := report.Totals()
+	assert.Equal(t, []int{3, 1, 1}, []int{passed, failed, skipped}, "the parent of the failing subtest is not counted")
+	assert.Equal(t, 1500*time.Millisecond, report.Elapsed())
+}
+
+func TestGoTestReportBox(t *testing.T) {
+	report, err := NewGoTestReader(strings.NewReader(goTestStream)).ReadReport()
+	require.NoError(t, err)
+
+	b := report.Box(true)
+
+	assert.Equal(t, box.Error, b.Type)
+	assert.Equal(t, "Go Tests", b.Title)
+	assert.Equal(t, "3 passed • 1 failed • 1 skipped", b.Subtitle)
+	assert.Equal(t, []box.KV{
+		{Key: "bad", Value: "2 passed • 1 failed (10ms)", Status: box.Error},
+		{Key: "nobuild", Value: "build failed (0s)", Status: box.Error},
+		{Key: "ok", Value: "1 passed • 1 skipped (1.23s)", Status: box.Success},
+	}, b.KVPairs)
+	assert.Equal(t, `✖ TestTable/empty (bad)
+    bad_test.go:5: got "", want "x"
+✖ nobuild
+    nobuild/x_test.go:3:28: undefined: undefined`, b.Body)
+	assert.Equal(t, "1.5s total • 1 package without tests", b.Footer)
+}
+
+func TestGoTestReportBoxOutcomes(t *testing.T) {
+	pass := `{"Action":"pass","Package":"example.com/a","Test":"TestA"}
+{"Action":"pass","Package":"example.com/a","Elapsed":0.5}
+`
+	tests := []struct {
+		name       string
+		stream     string
+		done       bool
+		wantType   box.BoxType
+		wantValues []string
+	}{
+		{name: "all passed", stream: pass, done: true, wantType: box.Success, wantValues: []string{"1 passed (500ms)"}},
+		{name: "no tests", stream: `{"Action":"skip","Package":"example.com/a"}`, done: true, wantType: box.Warning},
+		{name: "only skipped", stream: "{\"Action\":\"skip\",\"Package\":\"example.com/a\",\"Test\":\"TestA\"}\n{\"Action\":\"pass\",\"Package\":\"example.com/a\"}", done: true, wantType: box.Warning, wantValues: []string{"1 skipped (0s)"}},
+		{name: "running", stream: `{"Action":"pass","Package":"example.com/a","Test":"TestA"}`, done: false, wantType: box.Pending, wantValues: []string{"1 passed • running"}},
+		{name: "cut off", stream: `{"Action":"pass","Package":"example.com/a","Test":"TestA"}`, done: true, wantType: box.Error, wantValues: []string{"1 passed • no result"}},
+		{name: "running before first result", stream: `{"Action":"start","Package":"example.com/a"}`, done: false, wantType: box.Pending, wantValues: []string{"running"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := NewGoTestReader(strings.NewReader(tt.stream)).ReadReport()
+			require.NoError(t, err)
+
+			b := report.Box(tt.done)
+			assert.Equal(t, tt.wantType, b.Type)
+			var values []string
+			for _, kv := range b.KVPairs {
+				values = append(values, kv.Value)
+			}
+			assert.Equal(t, tt.wantValues, values)
+		})
+	}
+}
+
+func TestCommonPackagePrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []string
+		want     string
+	}{
+		{name: "single package keeps full path", packages: []string{"example.com/app/a"}, want: ""},
+		{name: "siblings", packages: []string{"example.com/app/a", "example.com/app/b"}, want: "example.com/app/"},
+		{name: "nested", packages: []string{"example.com/app", "example.com/app/internal/x"}, want: "example.com/"},
+		{name: "shared name prefix", packages: []string{"example.com/app/parse", "example.com/app/parser"}, want: "example.com/app/"},
+		{name: "nothing shared", packages: []string{"a", "b"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var packages []*GoTestPackage
+			for _, name := range tt.packages {
+				packages = append(packages, &GoTestPackage{Name: name})
+			}
+			assert.Equal(t, tt.want, commonPackagePrefix(packages))
+		})
+	}
+}
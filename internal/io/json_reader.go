@@ -1,23 +1,92 @@
 package io
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
+	"boxed/internal/box"
+	"boxed/internal/format"
 	"boxed/internal/parser"
+	"boxed/internal/validate"
 )
 
 // JSONBox represents the JSON structure for defining a complete box.
 // This allows tools that output JSON to easily generate boxed output without
-// constructing complex shell command lines.
+// constructing complex shell command lines. KV values are usually strings, but
+// numbers, booleans and arrays of numbers (rendered as sparklines) are accepted
+// so tools can emit metrics without stringifying them first. An object of the
+// form {"value": ..., "status": "error"} attaches a per-row status, and
+// {"bytes": 2576980377} (or duration, number, percent, ago) a typed value that
+// is formatted for display, and {"text": "#123", "url": "https://..."} a link.
+//
+// Type is only consulted when several boxes are read at once (grids) and for
+// children; single-box commands take the type from the subcommand instead.
+// Children nest recursively and render inside the parent's content area.
 type JSONBox struct {
-	Title       string            `json:"title"`
-	Subtitle    string            `json:"subtitle"`
-	KV          map[string]string `json:"kv"`
-	Footer      string            `json:"footer"`
-	Width       int               `json:"width"`
-	BorderStyle string            `json:"border_style"`
+	Type         string    `json:"type"`
+	Title        string    `json:"title"`
+	Subtitle     string    `json:"subtitle"`
+	KV           JSONKV    `json:"kv"`
+	Body         string    `json:"body"`
+	Footer       string    `json:"footer"`
+	Width        int       `json:"width"`
+	BorderStyle  string    `json:"border_style"`
+	Rules        []string  `json:"rules"`
+	Locale       string    `json:"locale"`
+	KeyAlign     string    `json:"key_align"`
+	Separator    string    `json:"separator"`
+	AlignNumbers *bool     `json:"align_numbers"`
+	Compact      *bool     `json:"compact"`
+	Columns      any       `json:"columns"`
+	Raw          *bool     `json:"raw"`
+	Children     []JSONBox `json:"children"`
+}
+
+// JSONKV is the "kv" object with its keys in document order. Decoding into a map
+// would lose it, and rows that reshuffle on every redraw make watch mode unreadable.
+type JSONKV []JSONField
+
+// JSONField is one key of the "kv" object.
+type JSONField struct {
+	Key   string
+	Value any
+}
+
+// UnmarshalJSON reads the object token by token to keep its key order.
+func (kv *JSONKV) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		*kv = nil
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("kv must be an object")
+	}
+
+	var fields JSONKV
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		// Object keys are always strings.
+		fields = append(fields, JSONField{Key: token.(string), Value: value})
+	}
+	*kv = fields
+	return nil
 }
 
 // JSONReader parses box definitions from JSON input.
@@ -31,7 +100,7 @@ func NewJSONReader(r io.Reader) *JSONReader {
 }
 
 // ReadBox parses a JSON box definition into parser.Options.
-// The JSON format uses a map for KV pairs which is more natural in JSON
+// The JSON format uses an object for KV pairs which is more natural in JSON
 // than an array of "key=value" strings.
 func (j *JSONReader) ReadBox() (parser.Options, error) {
 	var jsonBox JSONBox
@@ -41,17 +110,223 @@ func (j *JSONReader) ReadBox() (parser.Options, error) {
 		return parser.Options{}, fmt.Errorf("failed to decode JSON: %w", err)
 	}
 
+	return jsonBox.Options()
+}
+
+// ReadBoxes parses several box definitions, accepting either a single JSON array
+// or a stream of objects (NDJSON). Both shapes are common in tool output, and
+// sniffing the first token avoids a flag that users would have to get right.
+func (j *JSONReader) ReadBoxes() ([]JSONBox, error) {
+	buffered := bufio.NewReader(j.reader)
+	decoder := json.NewDecoder(buffered)
+
+	first, err := peekNonSpace(buffered)
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if first == '[' {
+		var boxes []JSONBox
+		if err := decoder.Decode(&boxes); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+		return boxes, nil
+	}
+
+	var boxes []JSONBox
+	for decoder.More() {
+		var jsonBox JSONBox
+		if err := decoder.Decode(&jsonBox); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON box %d: %w", len(boxes)+1, err)
+		}
+		boxes = append(boxes, jsonBox)
+	}
+	return boxes, nil
+}
+
+// peekNonSpace returns the first non-whitespace byte without consuming it.
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return b, r.UnreadByte()
+		}
+	}
+}
+
+// Options converts the JSON definition into parser.Options so JSON input goes
+// through the same parsing and validation as CLI flags.
+func (j JSONBox) Options() (parser.Options, error) {
 	opts := parser.Options{
-		Title:       jsonBox.Title,
-		Subtitle:    jsonBox.Subtitle,
-		Footer:      jsonBox.Footer,
-		Width:       jsonBox.Width,
-		BorderStyle: jsonBox.BorderStyle,
+		Title:       j.Title,
+		Subtitle:    j.Subtitle,
+		Body:        j.Body,
+		Footer:      j.Footer,
+		Width:       j.Width,
+		BorderStyle: j.BorderStyle,
+		Rules:       j.Rules,
+		Locale:      j.Locale,
+		Raw:         j.Raw,
+		Layout: box.KVLayout{
+			KeyAlign:  j.KeyAlign,
+			Separator: j.Separator,
+		},
+		AlignNumbers: j.AlignNumbers,
+		Compact:      j.Compact,
 	}
 
-	for key, value := range jsonBox.KV {
-		opts.KVFlags = append(opts.KVFlags, fmt.Sprintf("%s=%s", key, value))
+	// Columns is a number or "auto", so it's read like a KV value.
+	columns, err := jsonScalarToString(j.Columns)
+	if err != nil {
+		return parser.Options{}, fmt.Errorf("invalid columns: %w", err)
+	}
+	opts.Columns = columns
+
+	for _, field := range j.KV {
+		text, err := jsonValueToString(field.Value)
+		if err != nil {
+			return parser.Options{}, fmt.Errorf("invalid value for key %q: %w", field.Key, err)
+		}
+		opts.KVPairs = append(opts.KVPairs, box.KV{Key: field.Key, Value: text})
+	}
+
+	for i, child := range j.Children {
+		childOpts, err := child.Options()
+		if err != nil {
+			return parser.Options{}, fmt.Errorf("child box %d: %w", i+1, err)
+		}
+		opts.Children = append(opts.Children, parser.Child{Type: child.BoxType(), Options: childOpts})
 	}
 
 	return opts, nil
 }
+
+// BoxType returns the declared type, falling back to parser.AutoType so boxes
+// without a type are derived from their rules and row statuses.
+func (j JSONBox) BoxType() string {
+	if j.Type == "" {
+		return parser.AutoType
+	}
+	return j.Type
+}
+
+// jsonValueToString converts a decoded JSON value into the same textual form
+// accepted by --kv, so JSON input flows through the regular parser. Arrays of
+// numbers become "@spark:" series and status objects become "value!status".
+func jsonValueToString(value any) (string, error) {
+	if obj, ok := value.(map[string]any); ok {
+		return jsonStatusValueToString(obj)
+	}
+	return jsonScalarToString(value)
+}
+
+// jsonStatusValueToString handles the {"value": ..., "status": ...} object form
+// and typed values such as {"bytes": 1024}, which may carry a status too.
+// The status is validated here rather than left to the parser because the parser
+// treats unknown suffixes as part of the value, which would silently hide typos.
+func jsonStatusValueToString(obj map[string]any) (string, error) {
+	text, err := jsonObjectValue(obj)
+	if err != nil {
+		return "", err
+	}
+
+	rawStatus, ok := obj["status"]
+	if !ok || rawStatus == nil {
+		return text, nil
+	}
+
+	status, ok := rawStatus.(string)
+	if !ok {
+		return "", fmt.Errorf("status must be a string")
+	}
+	if status == "" {
+		return text, nil
+	}
+	if err := validate.BoxType(status); err != nil {
+		return "", fmt.Errorf("invalid status: %w", err)
+	}
+
+	return text + parser.StatusSeparator + status, nil
+}
+
+// jsonObjectValue returns the "value" of an object, its typed value as the
+// "@<kind>:" form accepted by --kv, or its link as "[text](url)". Exactly one of
+// them may be given.
+func jsonObjectValue(obj map[string]any) (string, error) {
+	var keys []string
+	for _, key := range append([]string{"value", "url"}, format.Kinds...) {
+		if _, ok := obj[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) > 1 {
+		return "", fmt.Errorf("object has both %q and %q, only one value is allowed", keys[0], keys[1])
+	}
+	if len(keys) == 0 || keys[0] == "value" {
+		return jsonScalarToString(obj["value"])
+	}
+	if keys[0] == "url" {
+		return jsonLinkToString(obj)
+	}
+
+	kind := keys[0]
+	switch raw := obj[kind].(type) {
+	case string:
+		return "@" + kind + ":" + raw, nil
+	case float64:
+		return "@" + kind + ":" + strconv.FormatFloat(raw, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%s must be a number or a string", kind)
+	}
+}
+
+// jsonLinkToString turns {"text": ..., "url": ...} into "[text](url)"; the text
+// defaults to the URL.
+func jsonLinkToString(obj map[string]any) (string, error) {
+	url, ok := obj["url"].(string)
+	if !ok || url == "" {
+		return "", fmt.Errorf("url must be a non-empty string")
+	}
+	if strings.ContainsAny(url, " \t\n") {
+		return "", fmt.Errorf("url must not contain whitespace")
+	}
+	text, err := jsonScalarToString(obj["text"])
+	if err != nil {
+		return "", err
+	}
+	if text == "" {
+		text = url
+	}
+	return "[" + text + "](" + url + ")", nil
+}
+
+func jsonScalarToString(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		samples := make([]string, 0, len(v))
+		for _, item := range v {
+			n, ok := item.(float64)
+			if !ok {
+				return "", fmt.Errorf("arrays must contain only numbers")
+			}
+			samples = append(samples, strconv.FormatFloat(n, 'f', -1, 64))
+		}
+		return parser.SparkPrefix + strings.Join(samples, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
@@ -0,0 +1,234 @@
+package io
+
+import (
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONReader_ReadBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantKV  []string
+		wantErr bool
+	}{
+		{
+			name:   "string value",
+			input:  `{"title":"Status","kv":{"CPU":"45%"}}`,
+			wantKV: []string{"CPU=45%"},
+		},
+		{
+			name:   "number value",
+			input:  `{"kv":{"Count":3}}`,
+			wantKV: []string{"Count=3"},
+		},
+		{
+			name:   "numeric array becomes sparkline",
+			input:  `{"kv":{"Latency":[12,15.5,40]}}`,
+			wantKV: []string{"Latency=@spark:12,15.5,40"},
+		},
+		{
+			name:    "mixed array",
+			input:   `{"kv":{"Latency":[12,"fast"]}}`,
+			wantErr: true,
+		},
+		{
+			name:   "status object",
+			input:  `{"kv":{"db":{"value":"down","status":"error"}}}`,
+			wantKV: []string{"db=down!error"},
+		},
+		{
+			name:   "status object with series",
+			input:  `{"kv":{"Latency":{"value":[1,2],"status":"warning"}}}`,
+			wantKV: []string{"Latency=@spark:1,2!warning"},
+		},
+		{
+			name:   "status object without status",
+			input:  `{"kv":{"db":{"value":"up"}}}`,
+			wantKV: []string{"db=up"},
+		},
+		{
+			name:    "status object with unknown status",
+			input:   `{"kv":{"db":{"value":"down","status":"broken"}}}`,
+			wantErr: true,
+		},
+		{
+			name:   "typed value",
+			input:  `{"kv":{"Size":{"bytes":2576980377}}}`,
+			wantKV: []string{"Size=@bytes:2576980377"},
+		},
+		{
+			name:   "typed value with status",
+			input:  `{"kv":{"Last backup":{"ago":"2024-05-01T10:00:00Z","status":"warning"}}}`,
+			wantKV: []string{"Last backup=@ago:2024-05-01T10:00:00Z!warning"},
+		},
+		{
+			name:    "typed value and value",
+			input:   `{"kv":{"Size":{"bytes":1,"value":"1 B"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "typed value of the wrong type",
+			input:   `{"kv":{"Size":{"bytes":[1,2]}}}`,
+			wantErr: true,
+		},
+		{
+			name:   "link",
+			input:  `{"kv":{"PR":{"text":"#123","url":"https://github.com/org/repo/pull/123","status":"success"}}}`,
+			wantKV: []string{"PR=[#123](https://github.com/org/repo/pull/123)!success"},
+		},
+		{
+			name:   "link without text",
+			input:  `{"kv":{"Docs":{"url":"https://example.com"}}}`,
+			wantKV: []string{"Docs=[https://example.com](https://example.com)"},
+		},
+		{
+			name:   "link with commas in its query",
+			input:  `{"kv":{"Search":{"text":"results","url":"https://example.com/?q=a,b=c"}}}`,
+			wantKV: []string{"Search=[results](https://example.com/?q=a,b=c)"},
+		},
+		{
+			name:    "link with a value",
+			input:   `{"kv":{"PR":{"value":"#123","url":"https://example.com"}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "nested object value",
+			input:   `{"kv":{"Nested":{"value":{"a":1}}}}`,
+			wantErr: true,
+		},
+		{
+			name:   "keys keep their order",
+			input:  `{"kv":{"Zone":"eu","CPU":"45%","Memory":{"value":"80%","status":"warning"},"Disk":null}}`,
+			wantKV: []string{"Zone=eu", "CPU=45%", "Memory=80%!warning", "Disk="},
+		},
+		{
+			name:    "kv must be an object",
+			input:   `{"kv":["CPU=45%"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed JSON",
+			input:   `{"kv":`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := NewJSONReader(strings.NewReader(tt.input))
+
+			opts, err := reader.ReadBox()
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				var kvs []string
+				for _, kv := range opts.KVPairs {
+					kvs = append(kvs, kv.String())
+				}
+				assert.Equal(t, tt.wantKV, kvs)
+			}
+		})
+	}
+}
+
+func TestJSONReader_ReadBoxes(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantTitles []string
+		wantErr    bool
+	}{
+		{
+			name:       "single object",
+			input:      `{"title":"A"}`,
+			wantTitles: []string{"A"},
+		},
+		{
+			name:       "array",
+			input:      `  [{"title":"A"},{"title":"B","type":"error"}]`,
+			wantTitles: []string{"A", "B"},
+		},
+		{
+			name:       "NDJSON",
+			input:      "{\"title\":\"A\"}\n{\"title\":\"B\"}\n{\"title\":\"C\"}\n",
+			wantTitles: []string{"A", "B", "C"},
+		},
+		{
+			name:       "empty input",
+			input:      "\n",
+			wantTitles: nil,
+		},
+		{
+			name:    "malformed second object",
+			input:   "{\"title\":\"A\"}\n{\"title\":\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boxes, err := NewJSONReader(strings.NewReader(tt.input)).ReadBoxes()
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var titles []string
+			for _, b := range boxes {
+				titles = append(titles, b.Title)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+		})
+	}
+}
+
+func TestJSONBox_BoxType(t *testing.T) {
+	assert.Equal(t, "error", JSONBox{Type: "error"}.BoxType())
+	assert.Equal(t, "auto", JSONBox{}.BoxType())
+}
+
+func TestJSONBox_OptionsChildren(t *testing.T) {
+	input := `{"title":"Release","children":[{"type":"success","title":"api"},{"title":"worker","children":[{"title":"job"}]}]}`
+
+	opts, err := NewJSONReader(strings.NewReader(input)).ReadBox()
+
+	require.NoError(t, err)
+	require.Len(t, opts.Children, 2)
+	assert.Equal(t, "success", opts.Children[0].Type)
+	assert.Equal(t, "api", opts.Children[0].Options.Title)
+	assert.Equal(t, "auto", opts.Children[1].Type)
+	require.Len(t, opts.Children[1].Options.Children, 1)
+	assert.Equal(t, "job", opts.Children[1].Options.Children[0].Options.Title)
+}
+
+func TestJSONBox_OptionsLayout(t *testing.T) {
+	input := `{"title":"Cluster","locale":"de","key_align":"right","separator":"dots","align_numbers":true,"compact":true,"columns":3,"raw":true}`
+
+	opts, err := NewJSONReader(strings.NewReader(input)).ReadBox()
+
+	require.NoError(t, err)
+	assert.Equal(t, "de", opts.Locale)
+	assert.Equal(t, box.KVLayout{KeyAlign: "right", Separator: "dots"}, opts.Layout)
+	require.NotNil(t, opts.AlignNumbers)
+	assert.True(t, *opts.AlignNumbers)
+	require.NotNil(t, opts.Compact)
+	assert.True(t, *opts.Compact)
+	assert.Equal(t, "3", opts.Columns)
+	require.NotNil(t, opts.Raw)
+	assert.True(t, *opts.Raw)
+
+	opts, err = NewJSONReader(strings.NewReader(`{"columns":"auto"}`)).ReadBox()
+	require.NoError(t, err)
+	assert.Equal(t, "auto", opts.Columns)
+	assert.Nil(t, opts.Compact, "missing switches leave the flags and config in charge")
+	assert.Nil(t, opts.Raw)
+}
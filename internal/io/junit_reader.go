@@ -0,0 +1,136 @@
+package io
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// junitSuite covers both <testsuite> and <testsuites>; the latter is just a suite
+// that holds other suites, and some tools nest suites further.
+type junitSuite struct {
+	XMLName xml.Name     `xml:""`
+	Name    string       `xml:"name,attr"`
+	Time    string       `xml:"time,attr"`
+	Suites  []junitSuite `xml:"testsuite"`
+	Cases   []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *junitProblem `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitReader parses JUnit XML reports, the de facto interchange format for test
+// results (Maven Surefire, pytest --junitxml, Jest, Gradle...).
+type JUnitReader struct {
+	reader io.Reader
+	name   string
+}
+
+// NewJUnitReader creates a reader for a JUnit XML report. name identifies the report
+// in errors and names suites that don't have a name of their own.
+func NewJUnitReader(r io.Reader, name string) *JUnitReader {
+	return &JUnitReader{reader: r, name: name}
+}
+
+// ReadResults counts the <testcase> elements of every suite with cases. The count
+// attributes on <testsuite> are ignored because tools disagree on them (whether
+// tests includes skipped, errors vs failures); the cases themselves are unambiguous.
+func (j *JUnitReader) ReadResults() (*TestResults, error) {
+	var root junitSuite
+	if err := xml.NewDecoder(j.reader).Decode(&root); err != nil {
+		return nil, fmt.Errorf("%s: invalid JUnit XML: %w", j.name, err)
+	}
+	if root.XMLName.Local != "testsuites" && root.XMLName.Local != "testsuite" {
+		return nil, fmt.Errorf("%s: expected <testsuites> or <testsuite>, got <%s>", j.name, root.XMLName.Local)
+	}
+
+	results := &TestResults{}
+	if err := j.collect(root, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (j *JUnitReader) collect(suite junitSuite, results *TestResults) error {
+	for _, child := range suite.Suites {
+		if err := j.collect(child, results); err != nil {
+			return err
+		}
+	}
+	if len(suite.Cases) == 0 {
+		return nil
+	}
+
+	result := TestSuiteResult{Name: suite.Name, Tests: len(suite.Cases)}
+	if result.Name == "" {
+		result.Name = j.name
+	}
+	var caseTime time.Duration
+	for _, c := range suite.Cases {
+		d, err := parseSeconds(c.Time)
+		if err != nil {
+			return fmt.Errorf("%s: test case %q: %w", j.name, c.Name, err)
+		}
+		caseTime += d
+
+		name := c.Name
+		if c.ClassName != "" && c.ClassName != suite.Name {
+			name = c.ClassName + "." + c.Name
+		}
+		switch {
+		case c.Error != nil:
+			result.Errors++
+			results.Failed = append(results.Failed, FailedCase{Suite: result.Name, Name: name, Error: true, Message: c.Error.message()})
+		case c.Failure != nil:
+			result.Failures++
+			results.Failed = append(results.Failed, FailedCase{Suite: result.Name, Name: name, Message: c.Failure.message()})
+		case c.Skipped != nil:
+			result.Skipped++
+		}
+	}
+
+	// The suite time includes setup and teardown, so prefer it over the sum of cases.
+	suiteTime, err := parseSeconds(suite.Time)
+	if err != nil {
+		return fmt.Errorf("%s: test suite %q: %w", j.name, suite.Name, err)
+	}
+	result.Duration = max(suiteTime, caseTime)
+
+	results.Suites = append(results.Suites, result)
+	return nil
+}
+
+func (p *junitProblem) message() string {
+	if p.Message != "" {
+		return p.Message
+	}
+	return strings.TrimSpace(p.Text)
+}
+
+// parseSeconds reads JUnit time attributes: decimal seconds, sometimes with
+// thousands separators ("1,234.5") from locale-aware tools.
+func parseSeconds(s string) (time.Duration, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if s == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
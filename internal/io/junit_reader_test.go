@@ -0,0 +1,85 @@
+package io
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnitReader(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantSuites []TestSuiteResult
+		wantFailed []FailedCase
+		wantErr    string
+	}{
+		{
+			name: "surefire style testsuites",
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="all" tests="5" failures="1" errors="1">
+  <testsuite name="com.example.CartTest" tests="3" time="1.5">
+    <testcase name="addsItem" classname="com.example.CartTest" time="0.2"/>
+    <testcase name="removesItem" classname="com.example.CartTest" time="0.3">
+      <failure message="expected 1 but was 2" type="AssertionError">stack trace</failure>
+    </testcase>
+    <testcase name="pending" classname="com.example.CartTest"><skipped/></testcase>
+  </testsuite>
+  <testsuite name="com.example.DbTest" time="0.5">
+    <testcase name="connects" classname="com.example.DbTest" time="0.5">
+      <error type="IOException">
+        connection refused
+        at Db.connect
+      </error>
+    </testcase>
+  </testsuite>
+</testsuites>`,
+			wantSuites: []TestSuiteResult{
+				{Name: "com.example.CartTest", Tests: 3, Failures: 1, Skipped: 1, Duration: 1500 * time.Millisecond},
+				{Name: "com.example.DbTest", Tests: 1, Errors: 1, Duration: 500 * time.Millisecond},
+			},
+			wantFailed: []FailedCase{
+				{Suite: "com.example.CartTest", Name: "removesItem", Message: "expected 1 but was 2"},
+				{Suite: "com.example.DbTest", Name: "connects", Error: true, Message: "connection refused\n        at Db.connect"},
+			},
+		},
+		{
+			name: "pytest single testsuite",
+			input: `<testsuite name="pytest" time="1,234.5">
+  <testcase classname="tests.test_api" name="test_get" time="0.01"/>
+  <testcase classname="tests.test_api" name="test_post" time="0.02"><failure message="assert 404 == 200"/></testcase>
+</testsuite>`,
+			wantSuites: []TestSuiteResult{{Name: "pytest", Tests: 2, Failures: 1, Duration: 1234500 * time.Millisecond}},
+			wantFailed: []FailedCase{{Suite: "pytest", Name: "tests.test_api.test_post", Message: "assert 404 == 200"}},
+		},
+		{
+			name:       "suite without name or time",
+			input:      `<testsuite><testcase name="a" time="0.25"/><testcase name="b" time="0.5"/></testsuite>`,
+			wantSuites: []TestSuiteResult{{Name: "report.xml", Tests: 2, Duration: 750 * time.Millisecond}},
+		},
+		{
+			name:       "nested suites without cases are skipped",
+			input:      `<testsuites><testsuite name="outer"><testsuite name="inner"><testcase name="a"/></testsuite></testsuite></testsuites>`,
+			wantSuites: []TestSuiteResult{{Name: "inner", Tests: 1}},
+		},
+		{name: "not junit", input: `<html></html>`, wantErr: "report.xml: expected <testsuites> or <testsuite>, got <html>"},
+		{name: "invalid xml", input: `<testsuite>`, wantErr: "report.xml: invalid JUnit XML: XML syntax error on line 1: unexpected EOF"},
+		{name: "invalid time", input: `<testsuite><testcase name="a" time="soon"/></testsuite>`, wantErr: `report.xml: test case "a": invalid time "soon"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := NewJUnitReader(strings.NewReader(tt.input), "report.xml").ReadResults()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSuites, results.Suites)
+			assert.Equal(t, tt.wantFailed, results.Failed)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package io
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// formatTable aligns rows of cells into columns for a box body. Body text keeps its
+// spacing, so padding every column but the last to its widest cell lines them up;
+// the last column is left ragged so long messages don't pad short rows.
+func formatTable(rows [][]string) string {
+	var widths []int
+	for _, row := range rows {
+		for col := 0; col < len(row)-1; col++ {
+			if col == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[col] = max(widths[col], ansi.StringWidth(row[col]))
+		}
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		var line strings.Builder
+		for col, cell := range row {
+			line.WriteString(cell)
+			if col < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[col]-ansi.StringWidth(cell)+2))
+			}
+		}
+		lines[i] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,109 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	tapPlan = regexp.MustCompile(`^1\.\.(\d+)`)
+	// tapResult matches "ok 1 - description # SKIP reason"; number, description and
+	// directive are all optional.
+	tapResult    = regexp.MustCompile(`^(not ok|ok)\b\s*(\d+)?\s*(?:-\s*)?([^#]*?)\s*(?:#\s*(\w+)\b\s*(.*))?$`)
+	tapYAMLField = regexp.MustCompile(`^\s+message:\s*(.*)$`)
+)
+
+// TAPReader parses Test Anything Protocol output (bats, prove, node-tap).
+type TAPReader struct {
+	reader io.Reader
+	name   string
+}
+
+// NewTAPReader creates a reader for a TAP stream. The stream becomes one suite named
+// name.
+func NewTAPReader(r io.Reader, name string) *TAPReader {
+	return &TAPReader{reader: r, name: name}
+}
+
+// ReadResults counts top-level test points; indented lines belong to subtests or
+// YAML diagnostics and only supply failure messages. "# TODO" failures are expected
+// and count as skipped. Tests promised by the plan but never reported, and "Bail out!",
+// count as errors so a crashed run can't pass.
+func (t *TAPReader) ReadResults() (*TestResults, error) {
+	suite := TestSuiteResult{Name: t.name}
+	results := &TestResults{}
+	planned := -1
+	bailedOut := ""
+	// diagnosing is the index of the failure whose diagnostics (comments and YAML
+	// directly after the "not ok" line) are being read, or -1.
+	diagnosing := -1
+
+	scanner := bufio.NewScanner(t.reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if diagnosing >= 0 {
+			if strings.HasPrefix(line, "#") || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+				failed := &results.Failed[diagnosing]
+				if m := tapYAMLField.FindStringSubmatch(line); m != nil {
+					failed.Message = strings.Trim(m[1], `"'`)
+				} else if comment, ok := strings.CutPrefix(line, "#"); ok && failed.Message == "" {
+					failed.Message = strings.TrimSpace(comment)
+				}
+				continue
+			}
+			diagnosing = -1
+		}
+
+		if m := tapPlan.FindStringSubmatch(line); m != nil {
+			planned, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if reason, ok := strings.CutPrefix(line, "Bail out!"); ok {
+			bailedOut = strings.TrimSpace(reason)
+			break
+		}
+
+		m := tapResult.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		suite.Tests++
+		name := m[3]
+		if name == "" {
+			name = "test " + m[2]
+		}
+		directive := strings.ToUpper(m[4])
+
+		switch {
+		case strings.HasPrefix(directive, "SKIP"), directive == "TODO" && m[1] == "not ok":
+			suite.Skipped++
+		case m[1] == "not ok":
+			suite.Failures++
+			results.Failed = append(results.Failed, FailedCase{Suite: t.name, Name: name})
+			diagnosing = len(results.Failed) - 1
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", t.name, err)
+	}
+
+	if bailedOut != "" || planned > suite.Tests {
+		missing := max(planned-suite.Tests, 0)
+		message := fmt.Sprintf("%d of %d planned tests did not run", missing, planned)
+		if bailedOut != "" {
+			message = "bailed out: " + bailedOut
+			missing = max(missing, 1)
+		}
+		suite.Tests += missing
+		suite.Errors += missing
+		results.Failed = append(results.Failed, FailedCase{Suite: t.name, Name: "(run)", Error: true, Message: message})
+	}
+
+	results.Suites = append(results.Suites, suite)
+	return results, nil
+}
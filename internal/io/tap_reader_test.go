@@ -0,0 +1,93 @@
+package io
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTAPReader(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantSuite  TestSuiteResult
+		wantFailed []FailedCase
+	}{
+		{
+			name: "bats output",
+			input: `1..4
+ok 1 installs package
+not ok 2 configures service
+# (in test file test/install.bats, line 12)
+#   ` + "`run systemctl start app' failed" + `
+ok 3 skipped on macOS # skip not supported
+ok 4 - uninstalls
+`,
+			wantSuite:  TestSuiteResult{Name: "install.tap", Tests: 4, Failures: 1, Skipped: 1},
+			wantFailed: []FailedCase{{Suite: "install.tap", Name: "configures service", Message: "(in test file test/install.bats, line 12)"}},
+		},
+		{
+			name: "TAP 13 with YAML diagnostics",
+			input: `TAP version 13
+ok 1 - parses config
+not ok 2 - rejects bad input
+  ---
+  message: 'expected an error'
+  severity: fail
+  ...
+not ok 3 - flaky network # TODO fix retries
+1..3
+`,
+			wantSuite:  TestSuiteResult{Name: "install.tap", Tests: 3, Failures: 1, Skipped: 1},
+			wantFailed: []FailedCase{{Suite: "install.tap", Name: "rejects bad input", Message: "expected an error"}},
+		},
+		{
+			name: "summary comments are not diagnostics",
+			input: `not ok 1
+ok 2 - fine
+# tests 2
+`,
+			wantSuite:  TestSuiteResult{Name: "install.tap", Tests: 2, Failures: 1},
+			wantFailed: []FailedCase{{Suite: "install.tap", Name: "test 1"}},
+		},
+		{
+			name: "plan not reached",
+			input: `1..5
+ok 1 - a
+ok 2 - b
+`,
+			wantSuite:  TestSuiteResult{Name: "install.tap", Tests: 5, Errors: 3},
+			wantFailed: []FailedCase{{Suite: "install.tap", Name: "(run)", Error: true, Message: "3 of 5 planned tests did not run"}},
+		},
+		{
+			name: "bail out",
+			input: `1..2
+ok 1 - a
+Bail out! database unavailable
+ok 2 - never counted
+`,
+			wantSuite:  TestSuiteResult{Name: "install.tap", Tests: 2, Errors: 1},
+			wantFailed: []FailedCase{{Suite: "install.tap", Name: "(run)", Error: true, Message: "bailed out: database unavailable"}},
+		},
+		{
+			name: "subtests are not counted",
+			input: `    ok 1 - inner
+    1..1
+ok 1 - outer
+1..1
+`,
+			wantSuite: TestSuiteResult{Name: "install.tap", Tests: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := NewTAPReader(strings.NewReader(tt.input), "install.tap").ReadResults()
+			require.NoError(t, err)
+			assert.Equal(t, []TestSuiteResult{tt.wantSuite}, results.Suites)
+			assert.Equal(t, tt.wantFailed, results.Failed)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package io
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// maxMessageWidth keeps the failure table within a normal terminal; the full
+// message is in the report file for anyone who needs it.
+const maxMessageWidth = 60
+
+// TestSuiteResult aggregates the cases of one suite (a JUnit <testsuite> or a TAP stream).
+type TestSuiteResult struct {
+	Name     string
+	Tests    int
+	Failures int
+	Errors   int
+	Skipped  int
+	Duration time.Duration
+}
+
+// FailedCase is a test case that failed or errored.
+type FailedCase struct {
+	Suite string
+	Name  string
+	// Error is set for JUnit <error> results (the test couldn't run properly) as
+	// opposed to assertion failures.
+	Error   bool
+	Message string
+}
+
+// TestResults aggregates suites from JUnit XML and TAP reports so results from
+// several tools and files can be shown in one box.
+type TestResults struct {
+	Suites []TestSuiteResult
+	Failed []FailedCase
+}
+
+// Merge appends the suites and failures of other.
+func (t *TestResults) Merge(other *TestResults) {
+	t.Suites = append(t.Suites, other.Suites...)
+	t.Failed = append(t.Failed, other.Failed...)
+}
+
+// Totals sums all suites.
+func (t *TestResults) Totals() TestSuiteResult {
+	var total TestSuiteResult
+	for _, suite := range t.Suites {
+		total.Tests += suite.Tests
+		total.Failures += suite.Failures
+		total.Errors += suite.Errors
+		total.Skipped += suite.Skipped
+		total.Duration += suite.Duration
+	}
+	return total
+}
+
+// Box summarizes the results with a row per suite. Failures and errors make it an
+// error box; a report where nothing ran, or everything was skipped, is a warning since
+// it usually means tests were filtered out by mistake. With showFailures the failed
+// cases are listed in a table below the rows.
+func (t *TestResults) Box(title string, showFailures bool) *box.Box {
+	total := t.Totals()
+	b := &box.Box{
+		Title:    title,
+		Subtitle: describeCounts(total),
+		Footer:   pluralize(len(t.Suites), "suite"),
+	}
+	// TAP has no timings; "in 0s" would suggest the tests didn't run.
+	if total.Duration > 0 {
+		b.Footer += " in " + formatTestDuration(total.Duration)
+	}
+
+	for _, suite := range t.Suites {
+		kv := box.KV{Key: suite.Name, Value: describeCounts(suite), Status: box.Success}
+		if suite.Duration > 0 {
+			kv.Value += fmt.Sprintf(" (%s)", formatTestDuration(suite.Duration))
+		}
+		switch {
+		case suite.Failures+suite.Errors > 0:
+			kv.Status = box.Error
+		case suite.Tests == suite.Skipped:
+			kv.Status = box.Warning
+		}
+		b.KVPairs = append(b.KVPairs, kv)
+	}
+
+	if showFailures && len(t.Failed) > 0 {
+		b.Body = failureTable(t.Failed)
+	}
+
+	switch {
+	case total.Failures+total.Errors > 0:
+		b.Type = box.Error
+	case total.Tests == total.Skipped:
+		b.Type = box.Warning
+	default:
+		b.Type = box.Success
+	}
+	return b
+}
+
+// describeCounts lists the passed count and every non-zero outcome.
+func describeCounts(s TestSuiteResult) string {
+	if s.Tests == 0 {
+		return "no tests"
+	}
+	parts := []string{fmt.Sprintf("%d passed", s.Tests-s.Failures-s.Errors-s.Skipped)}
+	if s.Failures > 0 {
+		parts = append(parts, fmt.Sprintf("%d failed", s.Failures))
+	}
+	if s.Errors > 0 {
+		parts = append(parts, pluralize(s.Errors, "error"))
+	}
+	if s.Skipped > 0 {
+		parts = append(parts, fmt.Sprintf("%d skipped", s.Skipped))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// failureTable lists failed cases with the first line of their message.
+func failureTable(failed []FailedCase) string {
+	rows := [][]string{{"Suite", "Test", "Message"}}
+	for i, c := range failed {
+		if i == maxListedFailures {
+			rows = append(rows, []string{fmt.Sprintf("… and %d more", len(failed)-maxListedFailures), "", ""})
+			break
+		}
+		marker := "✖ "
+		if c.Error {
+			marker = "! "
+		}
+		message, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		rows = append(rows, []string{c.Suite, marker + c.Name, ansi.Truncate(message, maxMessageWidth, "…")})
+	}
+	return formatTable(rows)
+}
+
+func pluralize(n int, noun string) string {
+	return fmt.Sprintf("%d %s", n, pluralNoun(n, noun))
+}
@@ -0,0 +1,82 @@
+package io
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestResultsBox(t *testing.T) {
+	results := &TestResults{
+		Suites: []TestSuiteResult{
+			{Name: "CartTest", Tests: 3, Failures: 1, Skipped: 1, Duration: 1500 * time.Millisecond},
+			{Name: "install.tap", Tests: 4, Errors: 1},
+		},
+		Failed: []FailedCase{
+			{Suite: "CartTest", Name: "removesItem", Message: "expected 1 but was 2\nstack trace"},
+			{Suite: "install.tap", Name: "(run)", Error: true, Message: "bailed out: database unavailable"},
+		},
+	}
+	passing := &TestResults{Suites: []TestSuiteResult{{Name: "ApiTest", Tests: 2, Duration: 250 * time.Millisecond}}}
+
+	merged := &TestResults{}
+	merged.Merge(results)
+	merged.Merge(passing)
+
+	b := merged.Box("Test Results", true)
+
+	assert.Equal(t, box.Error, b.Type)
+	assert.Equal(t, "Test Results", b.Title)
+	assert.Equal(t, "6 passed • 1 failed • 1 error • 1 skipped", b.Subtitle)
+	assert.Equal(t, "3 suites in 1.75s", b.Footer)
+	assert.Equal(t, []box.KV{
+		{Key: "CartTest", Value: "1 passed • 1 failed • 1 skipped (1.5s)", Status: box.Error},
+		{Key: "install.tap", Value: "3 passed • 1 error", Status: box.Error},
+		{Key: "ApiTest", Value: "2 passed (250ms)", Status: box.Success},
+	}, b.KVPairs)
+	assert.Equal(t, `Suite        Test           Message
+CartTest     ✖ removesItem  expected 1 but was 2
+install.tap  ! (run)        bailed out: database unavailable`, b.Body)
+
+	assert.Empty(t, merged.Box("Test Results", false).Body, "the failure table is optional")
+	assert.Equal(t, "1 suite", (&TestResults{Suites: []TestSuiteResult{{Name: "a.tap", Tests: 1}}}).Box("Tests", false).Footer)
+}
+
+func TestTestResultsBoxOutcomes(t *testing.T) {
+	tests := []struct {
+		name     string
+		suites   []TestSuiteResult
+		wantType box.BoxType
+	}{
+		{name: "all passed", suites: []TestSuiteResult{{Name: "a", Tests: 2, Skipped: 1}}, wantType: box.Success},
+		{name: "failures", suites: []TestSuiteResult{{Name: "a", Tests: 2, Failures: 1}}, wantType: box.Error},
+		{name: "errors", suites: []TestSuiteResult{{Name: "a", Tests: 2, Errors: 1}}, wantType: box.Error},
+		{name: "everything skipped", suites: []TestSuiteResult{{Name: "a", Tests: 2, Skipped: 2}}, wantType: box.Warning},
+		{name: "nothing ran", suites: nil, wantType: box.Warning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := &TestResults{Suites: tt.suites}
+			assert.Equal(t, tt.wantType, results.Box("Tests", false).Type)
+		})
+	}
+}
+
+func TestFailureTableLimitsRows(t *testing.T) {
+	var failed []FailedCase
+	for i := 0; i < maxListedFailures+3; i++ {
+		failed = append(failed, FailedCase{Suite: "s", Name: fmt.Sprintf("t%d", i), Message: strings.Repeat("x", 100)})
+	}
+
+	lines := strings.Split(failureTable(failed), "\n")
+
+	assert.Len(t, lines, maxListedFailures+2, "header, listed failures and a summary line")
+	assert.Equal(t, "… and 3 more", lines[len(lines)-1])
+	assert.True(t, strings.HasSuffix(lines[1], strings.Repeat("x", maxMessageWidth-1)+"…"), "long messages are truncated")
+}
@@ -0,0 +1,107 @@
+package live
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"boxed/internal/box"
+)
+
+// MaxOutputLines limits how much of a command's output is shown in a result box,
+// keeping it readable while still surfacing the usual "last line explains it" message.
+const MaxOutputLines = 5
+
+// spinnerFrames are braille dots, which stay one cell wide in every terminal font.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// PendingBox is the animated box shown while a command runs. The frame counter
+// selects the spinner glyph, so callers only need to increment it per redraw.
+func PendingBox(title string, command []string, frame int, elapsed time.Duration) *box.Box {
+	return &box.Box{
+		Type:  box.Pending,
+		Title: spinnerFrames[frame%len(spinnerFrames)] + " " + title,
+		KVPairs: []box.KV{
+			{Key: "Command", Value: strings.Join(command, " ")},
+			{Key: "Elapsed", Value: FormatElapsed(elapsed)},
+		},
+	}
+}
+
+// ResultBox builds the final box for a finished command and the exit code to propagate.
+// Errors that aren't exit statuses (e.g. the command was killed) map to exit code 1 so
+// a failure is never reported as success.
+func ResultBox(title string, command []string, elapsed time.Duration, runErr error, output string, interrupted bool) (*box.Box, int) {
+	kvPairs := []box.KV{
+		{Key: "Command", Value: strings.Join(command, " ")},
+		{Key: "Duration", Value: FormatElapsed(elapsed)},
+	}
+
+	if runErr == nil {
+		kvPairs = append(kvPairs, box.KV{Key: "Exit code", Value: "0"})
+		return &box.Box{Type: box.Success, Title: title, KVPairs: kvPairs}, 0
+	}
+
+	exitCode := 1
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) && exitErr.ExitCode() > 0 {
+		exitCode = exitErr.ExitCode()
+	}
+
+	status := fmt.Sprintf("%d", exitCode)
+	if interrupted {
+		status += " (interrupted)"
+	}
+	kvPairs = append(kvPairs, box.KV{Key: "Exit code", Value: status})
+
+	if tail := OutputTail(output, MaxOutputLines); tail != "" {
+		kvPairs = append(kvPairs, box.KV{Key: "Output", Value: tail})
+	}
+
+	return &box.Box{Type: box.Error, Title: title, KVPairs: kvPairs}, exitCode
+}
+
+// CommandErrorBox reports a failed run with the command, the error and the tail of
+// the command's stderr.
+func CommandErrorBox(title string, command []string, err error, stderr string) *box.Box {
+	b := &box.Box{
+		Type:  box.Error,
+		Title: title,
+		KVPairs: []box.KV{
+			{Key: "Command", Value: strings.Join(command, " ")},
+			{Key: "Error", Value: err.Error()},
+		},
+	}
+
+	if tail := OutputTail(stderr, MaxOutputLines); tail != "" {
+		b.KVPairs = append(b.KVPairs, box.KV{Key: "Stderr", Value: tail})
+	}
+
+	return b
+}
+
+// OutputTail joins the last n non-empty lines of output into a single line, since
+// KV values wrap on words and would otherwise lose the line structure anyway.
+func OutputTail(output string, n int) string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, " ")
+}
+
+// FormatElapsed rounds to a tenth of a second under a minute and to whole seconds
+// above, which is as much precision as a status line needs.
+func FormatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(100 * time.Millisecond).String()
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,77 @@
+package live
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingBox(t *testing.T) {
+	first := PendingBox("Deploying", []string{"./deploy.sh", "prod"}, 0, 1500*time.Millisecond)
+	next := PendingBox("Deploying", []string{"./deploy.sh", "prod"}, 1, 1500*time.Millisecond)
+
+	assert.Equal(t, box.Pending, first.Type)
+	assert.Equal(t, "⠋ Deploying", first.Title)
+	assert.Equal(t, "⠙ Deploying", next.Title)
+	assert.Equal(t, []box.KV{
+		{Key: "Command", Value: "./deploy.sh prod"},
+		{Key: "Elapsed", Value: "1.5s"},
+	}, first.KVPairs)
+}
+
+func TestResultBox(t *testing.T) {
+	b, code := ResultBox("Deploy", []string{"true"}, 2*time.Second, nil, "ignored", false)
+
+	assert.Equal(t, box.Success, b.Type)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, b.KVPairs, box.KV{Key: "Exit code", Value: "0"})
+	assert.NotContains(t, b.KVPairs, box.KV{Key: "Output", Value: "ignored"})
+}
+
+func TestResultBox_ExitStatus(t *testing.T) {
+	runErr := exec.Command("sh", "-c", "exit 3").Run()
+	require.Error(t, runErr)
+
+	b, code := ResultBox("Deploy", []string{"./deploy.sh"}, time.Second, runErr, "step 1\nstep 2 failed\n", false)
+
+	assert.Equal(t, box.Error, b.Type)
+	assert.Equal(t, 3, code)
+	assert.Contains(t, b.KVPairs, box.KV{Key: "Exit code", Value: "3"})
+	assert.Contains(t, b.KVPairs, box.KV{Key: "Output", Value: "step 1 step 2 failed"})
+}
+
+func TestResultBox_Interrupted(t *testing.T) {
+	b, code := ResultBox("Deploy", []string{"./deploy.sh"}, time.Second, errors.New("signal: killed"), "", true)
+
+	assert.Equal(t, box.Error, b.Type)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, b.KVPairs, box.KV{Key: "Exit code", Value: "1 (interrupted)"})
+}
+
+func TestCommandErrorBox(t *testing.T) {
+	b := CommandErrorBox("Command failed", []string{"./status.sh"}, errors.New("exit status 2"), "")
+
+	assert.Equal(t, box.Error, b.Type)
+	assert.Equal(t, []box.KV{
+		{Key: "Command", Value: "./status.sh"},
+		{Key: "Error", Value: "exit status 2"},
+	}, b.KVPairs)
+}
+
+func TestOutputTail(t *testing.T) {
+	assert.Equal(t, "c d", OutputTail("a\n\nb\nc\n\nd\n", 2))
+	assert.Equal(t, "a", OutputTail("a", 5))
+	assert.Empty(t, OutputTail("\n \n", 5))
+}
+
+func TestFormatElapsed(t *testing.T) {
+	assert.Equal(t, "1.2s", FormatElapsed(1234*time.Millisecond))
+	assert.Equal(t, "0s", FormatElapsed(20*time.Millisecond))
+	assert.Equal(t, "2m34s", FormatElapsed(154*time.Second+400*time.Millisecond))
+}
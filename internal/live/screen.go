@@ -0,0 +1,103 @@
+package live
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// ANSI sequences used for in-place redraws. They are written directly rather than
+// through a terminal library because only these few are needed and every terminal
+// that renders our gradients also understands them.
+const (
+	cursorPrevLine = "\x1b[%dF"
+	clearToEnd     = "\x1b[J"
+	clearScreen    = "\x1b[H\x1b[2J"
+	hideCursor     = "\x1b[?25l"
+	showCursor     = "\x1b[?25h"
+)
+
+// Screen redraws a multi-line frame in place by moving the cursor back to the start
+// of the previous frame and clearing below it. Unlike clearing the whole terminal, this
+// keeps earlier output (the command line, previous logs) visible and doesn't flicker.
+type Screen struct {
+	writer io.Writer
+	height int
+	width  int
+}
+
+// NewScreen creates a screen that draws frames to w. The writer is injected so tests
+// can inspect the escape sequences instead of needing a real terminal.
+func NewScreen(w io.Writer) *Screen {
+	return &Screen{writer: w}
+}
+
+// Draw replaces the previously drawn frame with a new one. The frame is followed by a
+// newline so the cursor rests below it, leaving the terminal usable if the program exits.
+func (s *Screen) Draw(frame string) error {
+	var out strings.Builder
+	if s.height > 0 {
+		fmt.Fprintf(&out, cursorPrevLine, s.height)
+	}
+	out.WriteString(clearToEnd)
+	out.WriteString(frame)
+	out.WriteString("\n")
+
+	s.height = s.rows(frame)
+	_, err := io.WriteString(s.writer, out.String())
+	return err
+}
+
+// SetWidth tells the screen how wide the terminal is so lines that wrap are counted
+// as the several rows they occupy. A width of 0 assumes no line wraps.
+func (s *Screen) SetWidth(width int) {
+	s.width = width
+}
+
+// rows counts terminal rows taken by a frame, including rows created by wrapping.
+func (s *Screen) rows(frame string) int {
+	lines := strings.Split(frame, "\n")
+	if s.width <= 0 {
+		return len(lines)
+	}
+
+	rows := 0
+	for _, line := range lines {
+		width := lipgloss.Width(line)
+		if width <= s.width {
+			rows++
+			continue
+		}
+		rows += (width + s.width - 1) / s.width
+	}
+	return rows
+}
+
+// Reset clears the terminal and forgets the previous frame. It is used after a resize:
+// once the terminal reflows wrapped lines, the cursor can no longer be moved back by
+// a known number of rows, so the only reliable redraw starts from a clean screen.
+func (s *Screen) Reset() error {
+	s.height = 0
+	_, err := io.WriteString(s.writer, clearScreen)
+	return err
+}
+
+// Forget keeps the current frame on screen but makes the next Draw start below it,
+// used when a final result should stay in the scrollback.
+func (s *Screen) Forget() {
+	s.height = 0
+}
+
+// HideCursor hides the cursor so it doesn't blink over the frame between redraws.
+func (s *Screen) HideCursor() error {
+	_, err := io.WriteString(s.writer, hideCursor)
+	return err
+}
+
+// ShowCursor restores the cursor; callers should defer it after HideCursor.
+func (s *Screen) ShowCursor() error {
+	_, err := io.WriteString(s.writer, showCursor)
+	return err
+}
@@ -0,0 +1,70 @@
+package live
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreen_Draw(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(&out)
+
+	assert.NoError(t, screen.Draw("line 1\nline 2\nline 3"))
+	assert.Equal(t, "\x1b[Jline 1\nline 2\nline 3\n", out.String(), "first frame doesn't move the cursor")
+
+	out.Reset()
+	assert.NoError(t, screen.Draw("only line"))
+	assert.Equal(t, "\x1b[3F\x1b[Jonly line\n", out.String(), "next frame moves up over the previous one")
+
+	out.Reset()
+	assert.NoError(t, screen.Draw("a\nb"))
+	assert.Equal(t, "\x1b[1F\x1b[Ja\nb\n", out.String())
+}
+
+func TestScreen_DrawCountsWrappedRows(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(&out)
+	screen.SetWidth(4)
+
+	assert.NoError(t, screen.Draw("abcdefghij\nab"))
+	out.Reset()
+	assert.NoError(t, screen.Draw("x"))
+
+	assert.Equal(t, "\x1b[4F\x1b[Jx\n", out.String(), "a 10-cell line wraps onto 3 rows of 4")
+}
+
+func TestScreen_Reset(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(&out)
+
+	assert.NoError(t, screen.Draw("a\nb"))
+	assert.NoError(t, screen.Reset())
+	out.Reset()
+	assert.NoError(t, screen.Draw("c"))
+
+	assert.Equal(t, "\x1b[Jc\n", out.String(), "draw after reset starts fresh")
+}
+
+func TestScreen_Forget(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(&out)
+
+	assert.NoError(t, screen.Draw("final"))
+	screen.Forget()
+	out.Reset()
+	assert.NoError(t, screen.Draw("next"))
+
+	assert.Equal(t, "\x1b[Jnext\n", out.String())
+}
+
+func TestScreen_Cursor(t *testing.T) {
+	var out bytes.Buffer
+	screen := NewScreen(&out)
+
+	assert.NoError(t, screen.HideCursor())
+	assert.NoError(t, screen.ShowCursor())
+
+	assert.Equal(t, "\x1b[?25l\x1b[?25h", out.String())
+}
@@ -0,0 +1,111 @@
+package live
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"boxed/internal/box"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Tail keeps the last N lines of a stream and counts lines matching error and
+// warning patterns, so a box can show both recent context and an overall verdict
+// without holding the whole stream in memory.
+type Tail struct {
+	size     int
+	lines    []string
+	total    int
+	errors   int
+	warnings int
+
+	errorPattern   *regexp.Regexp
+	warningPattern *regexp.Regexp
+}
+
+// NewTail creates a tail of size lines. Either pattern may be nil to disable it.
+func NewTail(size int, errorPattern, warningPattern *regexp.Regexp) *Tail {
+	return &Tail{
+		size:           size,
+		lines:          make([]string, 0, size),
+		errorPattern:   errorPattern,
+		warningPattern: warningPattern,
+	}
+}
+
+// Add records a line. A line matching the error pattern isn't also counted as a
+// warning, so "ERROR: deprecated warning" counts once, as the worse of the two.
+func (t *Tail) Add(line string) {
+	t.total++
+
+	plain := ansi.Strip(line)
+	switch {
+	case t.errorPattern != nil && t.errorPattern.MatchString(plain):
+		t.errors++
+	case t.warningPattern != nil && t.warningPattern.MatchString(plain):
+		t.warnings++
+	}
+
+	if len(t.lines) == t.size {
+		copy(t.lines, t.lines[1:])
+		t.lines = t.lines[:t.size-1]
+	}
+	t.lines = append(t.lines, line)
+}
+
+// Box renders the current state. While the stream is open the box is pending (or
+// error/warning as soon as a match is seen); once done it resolves to success when
+// nothing matched. The body is always padded to the tail size and the counts are
+// shown from the start, even at 0, so the box keeps a fixed height while redrawing;
+// lines are truncated to lineWidth so wrapping can't change it either.
+func (t *Tail) Box(title string, lineWidth int, done bool) *box.Box {
+	boxType := box.Pending
+	if done {
+		boxType = box.Success
+	}
+	if t.warnings > 0 {
+		boxType = box.Warning
+	}
+	if t.errors > 0 {
+		boxType = box.Error
+	}
+
+	body := make([]string, 0, t.size)
+	for _, line := range t.lines {
+		body = append(body, ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), lineWidth, "…"))
+	}
+	for len(body) < t.size {
+		body = append(body, "")
+	}
+
+	kvPairs := []box.KV{{Key: "Lines", Value: fmt.Sprintf("%d", t.total)}}
+	if t.errorPattern != nil {
+		kvPairs = append(kvPairs, countKV("Errors", t.errors, box.Error))
+	}
+	if t.warningPattern != nil {
+		kvPairs = append(kvPairs, countKV("Warnings", t.warnings, box.Warning))
+	}
+
+	subtitle := "streaming"
+	if done {
+		subtitle = fmt.Sprintf("last %d of %d lines", len(t.lines), t.total)
+	}
+
+	return &box.Box{
+		Type:     boxType,
+		Title:    title,
+		Subtitle: subtitle,
+		KVPairs:  kvPairs,
+		Body:     strings.Join(body, "\n"),
+	}
+}
+
+// countKV shows a match count, flagged with status once there is a match.
+func countKV(key string, count int, status box.BoxType) box.KV {
+	kv := box.KV{Key: key, Value: fmt.Sprintf("%d", count)}
+	if count > 0 {
+		kv.Status = status
+	}
+	return kv
+}
@@ -0,0 +1,91 @@
+package live
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTail_KeepsLastLines(t *testing.T) {
+	tail := NewTail(3, nil, nil)
+	for _, line := range []string{"one", "two", "three", "four", "five"} {
+		tail.Add(line)
+	}
+
+	b := tail.Box("Build", 80, true)
+
+	assert.Equal(t, "three\nfour\nfive", b.Body)
+	assert.Equal(t, box.Success, b.Type)
+	assert.Equal(t, "last 3 of 5 lines", b.Subtitle)
+	assert.Equal(t, []box.KV{{Key: "Lines", Value: "5"}}, b.KVPairs)
+}
+
+func TestTail_FixedHeightWhileStreaming(t *testing.T) {
+	tail := NewTail(4, nil, nil)
+	tail.Add("only line")
+
+	b := tail.Box("Build", 80, false)
+
+	assert.Equal(t, box.Pending, b.Type)
+	assert.Len(t, strings.Split(b.Body, "\n"), 4)
+}
+
+func TestTail_CountsShownFromTheStart(t *testing.T) {
+	tail := NewTail(4, regexp.MustCompile("error"), regexp.MustCompile("warn"))
+	tail.Add("compiling")
+
+	before := tail.Box("Build", 80, false)
+	tail.Add("error: boom")
+	after := tail.Box("Build", 80, false)
+
+	assert.Equal(t, []box.KV{{Key: "Lines", Value: "1"}, {Key: "Errors", Value: "0"}, {Key: "Warnings", Value: "0"}}, before.KVPairs)
+	assert.Len(t, after.KVPairs, len(before.KVPairs), "a match must not change the box height")
+	assert.Equal(t, box.KV{Key: "Errors", Value: "1", Status: box.Error}, after.KVPairs[1])
+}
+
+func TestTail_Patterns(t *testing.T) {
+	errorPattern := regexp.MustCompile(`(?i)error|fatal`)
+	warningPattern := regexp.MustCompile(`(?i)warn`)
+
+	tests := []struct {
+		name  string
+		lines []string
+		done  bool
+		want  box.BoxType
+	}{
+		{"clean stream in progress", []string{"compiling"}, false, box.Pending},
+		{"clean stream finished", []string{"compiling", "done"}, true, box.Success},
+		{"warning", []string{"WARN: deprecated flag"}, true, box.Warning},
+		{"error switches immediately", []string{"fatal: out of memory"}, false, box.Error},
+		{"error beats warning", []string{"warning: slow", "ERROR: failed"}, true, box.Error},
+		{"ANSI codes don't hide matches", []string{"\x1b[31mError\x1b[0m: failed"}, true, box.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tail := NewTail(5, errorPattern, warningPattern)
+			for _, line := range tt.lines {
+				tail.Add(line)
+			}
+
+			assert.Equal(t, tt.want, tail.Box("Build", 80, tt.done).Type)
+		})
+	}
+}
+
+func TestTail_CountsAndTruncation(t *testing.T) {
+	tail := NewTail(2, regexp.MustCompile("error"), regexp.MustCompile("warn"))
+	tail.Add("error: one")
+	tail.Add("warn: two")
+	tail.Add(strings.Repeat("x", 20))
+
+	b := tail.Box("Build", 10, true)
+
+	assert.Contains(t, b.KVPairs, box.KV{Key: "Errors", Value: "1", Status: box.Error})
+	assert.Contains(t, b.KVPairs, box.KV{Key: "Warnings", Value: "1", Status: box.Warning})
+	assert.Equal(t, "warn: two\nxxxxxxxxx…", b.Body)
+}
@@ -0,0 +1,236 @@
+package markup
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Span is a run of text with the same formatting. URL is set for link text and
+// Color holds one of Colors or a "#rrggbb" hex color.
+type Span struct {
+	Text   string
+	URL    string
+	Bold   bool
+	Italic bool
+	Code   bool
+	Strike bool
+	Color  string
+}
+
+// Colors are the names accepted in "{name}text{/}" color spans: the box types,
+// so text can match a status, and common color names.
+var Colors = []string{
+	"success", "error", "warning", "info", "pending",
+	"red", "green", "yellow", "blue", "magenta", "cyan", "orange", "gray", "white",
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// IsColor reports whether name can be used in a color span.
+func IsColor(name string) bool {
+	return slices.Contains(Colors, name) || hexColor.MatchString(name)
+}
+
+// escapable are the characters a backslash makes literal. Backslashes before other
+// characters are kept, so Windows paths don't need escaping.
+const escapable = "*`~{["
+
+// Parse splits s into spans. The syntax follows Markdown: **bold**, *italic*,
+// `code`, ~~strike~~ and [text](url) links, plus {red}color{/} spans. Styles nest,
+// except inside code. A marker only counts when it has a matching closer that
+// hugs the text, so "5 * 3" and "rm *.tmp" are left alone; anything else can be
+// escaped with a backslash.
+func Parse(s string) []Span {
+	return parse(s, Span{})
+}
+
+// parse splits s into spans that all carry the formatting of format.
+func parse(s string, format Span) []Span {
+	var spans []Span
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			span := format
+			span.Text = text.String()
+			spans = append(spans, span)
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(escapable, s[i+1]) >= 0 {
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+		if inner, n, ok := parseSpan(s[i:], format); ok {
+			flush()
+			spans = append(spans, inner...)
+			i += n
+			continue
+		}
+		text.WriteByte(s[i])
+		i++
+	}
+	flush()
+	return spans
+}
+
+// parseSpan reads a formatted span at the start of s and returns its length.
+func parseSpan(s string, format Span) (spans []Span, n int, ok bool) {
+	switch {
+	case s[0] == '`':
+		end := strings.IndexByte(s[1:], '`')
+		if end <= 0 {
+			return nil, 0, false
+		}
+		format.Code = true
+		format.Text = s[1 : end+1]
+		return []Span{format}, end + 2, true
+	case strings.HasPrefix(s, "**"):
+		format.Bold = true
+		return parseDelimited(s, "**", format)
+	case strings.HasPrefix(s, "~~"):
+		format.Strike = true
+		return parseDelimited(s, "~~", format)
+	case s[0] == '*':
+		format.Italic = true
+		return parseDelimited(s, "*", format)
+	case s[0] == '[':
+		label, url, n, ok := parseLink(s)
+		if !ok {
+			return nil, 0, false
+		}
+		format.URL = url
+		return parse(label, format), n, true
+	case s[0] == '{':
+		return parseColor(s, format)
+	}
+	return nil, 0, false
+}
+
+// parseDelimited reads text between two markers. The text may not start or end
+// with a space, and a single "*" is never half of a "**".
+func parseDelimited(s, marker string, format Span) ([]Span, int, bool) {
+	start := len(marker)
+	if start >= len(s) || s[start] == ' ' || (marker == "*" && s[start] == '*') {
+		return nil, 0, false
+	}
+	for j := start + 1; j+len(marker) <= len(s); j++ {
+		if !strings.HasPrefix(s[j:], marker) || s[j-1] == ' ' {
+			continue
+		}
+		if marker == "*" && (s[j-1] == '*' || (j+1 < len(s) && s[j+1] == '*')) {
+			continue
+		}
+		return parse(s[start:j], format), j + len(marker), true
+	}
+	return nil, 0, false
+}
+
+// parseColor reads "{name}text{/}". Color spans nest, so the closer is the one
+// that balances the opener.
+func parseColor(s string, format Span) ([]Span, int, bool) {
+	name, ok := colorTag(s)
+	if !ok {
+		return nil, 0, false
+	}
+	start := len(name) + 2
+	depth := 0
+	for j := start; j < len(s); j++ {
+		if strings.HasPrefix(s[j:], "{/}") {
+			if depth == 0 {
+				format.Color = name
+				return parse(s[start:j], format), j + 3, true
+			}
+			depth--
+			j += 2
+		} else if tag, ok := colorTag(s[j:]); ok {
+			depth++
+			j += len(tag) + 1
+		}
+	}
+	return nil, 0, false
+}
+
+// colorTag returns the color name of a "{name}" tag at the start of s.
+func colorTag(s string) (string, bool) {
+	if !strings.HasPrefix(s, "{") {
+		return "", false
+	}
+	end := strings.IndexByte(s, '}')
+	if end < 0 || !IsColor(s[1:end]) {
+		return "", false
+	}
+	return s[1:end], true
+}
+
+// parseLink reads "[label](url)" at the start of s and returns its length. The URL
+// may contain balanced parentheses, as Wikipedia links do, but no whitespace.
+func parseLink(s string) (label, url string, n int, ok bool) {
+	end := strings.Index(s, "](")
+	if end <= 1 || strings.ContainsAny(s[1:end], "[\n") {
+		return "", "", 0, false
+	}
+	label = s[1:end]
+
+	depth := 0
+	for i := end + 2; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+				continue
+			}
+			url = s[end+2 : i]
+			if url == "" {
+				return "", "", 0, false
+			}
+			return label, url, i + 1, true
+		case ' ', '\t', '\n':
+			return "", "", 0, false
+		}
+	}
+	return "", "", 0, false
+}
+
+// WithoutLinks replaces links by "text (url)" for terminals that can't show
+// hyperlinks. A link whose text is its URL is shown once. The URL part keeps the
+// link's other formatting.
+func WithoutLinks(spans []Span) []Span {
+	out := make([]Span, 0, len(spans))
+	for i, span := range spans {
+		url := span.URL
+		span.URL = ""
+		out = append(out, span)
+		// A styled label is split into several spans; the URL follows the last one.
+		if url == "" || (i+1 < len(spans) && spans[i+1].URL == url) {
+			continue
+		}
+		if label := linkLabel(spans, i); label != url {
+			out[len(out)-1].Text += " (" + url + ")"
+		}
+	}
+	return out
+}
+
+// linkLabel joins the text of the link ending at spans[end].
+func linkLabel(spans []Span, end int) string {
+	start := end
+	for start > 0 && spans[start-1].URL == spans[end].URL {
+		start--
+	}
+	return Plain(spans[start : end+1])
+}
+
+// Plain returns the text of the spans without formatting.
+func Plain(spans []Span) string {
+	var b strings.Builder
+	for _, span := range spans {
+		b.WriteString(span.Text)
+	}
+	return b.String()
+}
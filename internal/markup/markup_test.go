@@ -0,0 +1,110 @@
+package markup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Span
+	}{
+		{
+			name:  "plain text",
+			input: "no links here",
+			want:  []Span{{Text: "no links here"}},
+		},
+		{
+			name:  "link",
+			input: "[#123](https://github.com/org/repo/pull/123)",
+			want:  []Span{{Text: "#123", URL: "https://github.com/org/repo/pull/123"}},
+		},
+		{
+			name:  "link in text",
+			input: "see [the docs](https://example.com) first",
+			want:  []Span{{Text: "see "}, {Text: "the docs", URL: "https://example.com"}, {Text: " first"}},
+		},
+		{
+			name:  "parentheses in the URL",
+			input: "[Go](https://en.wikipedia.org/wiki/Go_(programming_language)).",
+			want:  []Span{{Text: "Go", URL: "https://en.wikipedia.org/wiki/Go_(programming_language)"}, {Text: "."}},
+		},
+		{
+			name:  "brackets that aren't links",
+			input: "[WARN] retry [2/3] (backoff)",
+			want:  []Span{{Text: "[WARN] retry [2/3] (backoff)"}},
+		},
+		{
+			name:  "whitespace in the URL",
+			input: "[a](b c)",
+			want:  []Span{{Text: "[a](b c)"}},
+		},
+		{
+			name:  "empty parts",
+			input: "[](x) [x]()",
+			want:  []Span{{Text: "[](x) [x]()"}},
+		},
+		{
+			name:  "emphasis",
+			input: "**bold** *italic* ~~gone~~ `go test`",
+			want: []Span{
+				{Text: "bold", Bold: true}, {Text: " "}, {Text: "italic", Italic: true}, {Text: " "},
+				{Text: "gone", Strike: true}, {Text: " "}, {Text: "go test", Code: true},
+			},
+		},
+		{
+			name:  "nested styles",
+			input: "**3 *new* failures**",
+			want:  []Span{{Text: "3 ", Bold: true}, {Text: "new", Bold: true, Italic: true}, {Text: " failures", Bold: true}},
+		},
+		{
+			name:  "no markup inside code",
+			input: "`**/*.go`",
+			want:  []Span{{Text: "**/*.go", Code: true}},
+		},
+		{
+			name:  "color",
+			input: "{error}3 failed{/}, {#ff8800}{bold}{/}",
+			want:  []Span{{Text: "3 failed", Color: "error"}, {Text: ", "}, {Text: "{bold}", Color: "#ff8800"}},
+		},
+		{
+			name:  "nested colors",
+			input: "{gray}a {red}b{/} c{/}",
+			want:  []Span{{Text: "a ", Color: "gray"}, {Text: "b", Color: "red"}, {Text: " c", Color: "gray"}},
+		},
+		{
+			name:  "styled link",
+			input: "[**#123** fixed](https://example.com)",
+			want:  []Span{{Text: "#123", URL: "https://example.com", Bold: true}, {Text: " fixed", URL: "https://example.com"}},
+		},
+		{
+			name:  "markers that aren't markup",
+			input: "5 * 3 * 2, rm *.tmp *.log, a ** b, {unknown}x{/}, ~~ ~~",
+			want:  []Span{{Text: "5 * 3 * 2, rm *.tmp *.log, a ** b, {unknown}x{/}, ~~ ~~"}},
+		},
+		{
+			name:  "escapes",
+			input: `\*not italic\* C:\Users`,
+			want:  []Span{{Text: `*not italic* C:\Users`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.input))
+		})
+	}
+}
+
+func TestWithoutLinks(t *testing.T) {
+	spans := Parse("PR [#123](https://example.com/123) and [https://example.com](https://example.com)")
+
+	assert.Equal(t, "PR #123 (https://example.com/123) and https://example.com", Plain(WithoutLinks(spans)))
+	assert.Equal(t, "PR #123 and https://example.com", Plain(spans), "the input is not modified")
+
+	styled := Parse("[**#1** fix](https://example.com/1)")
+	assert.Equal(t, []Span{{Text: "#1", Bold: true}, {Text: " fix (https://example.com/1)"}}, WithoutLinks(styled))
+}
@@ -1,9 +1,17 @@
 package parser
 
 import (
+	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"boxed/internal/box"
+	"boxed/internal/format"
+	"boxed/internal/markup"
+	"boxed/internal/redact"
+	"boxed/internal/rules"
 	"boxed/internal/validate"
 )
 
@@ -12,57 +20,206 @@ import (
 // flag parsing and our Box model, keeping CLI concerns separate from
 // domain logic.
 type Options struct {
-	Title       string
-	Subtitle    string
-	KVFlags     []string
+	Title    string
+	Subtitle string
+	KVFlags  []string
+	// KVPairs are pairs that arrive already split, from JSON or a template. They
+	// follow KVFlags, and only their values are interpreted (statuses, sparklines,
+	// typed values), so a value may contain text such as ",a=b" that --kv would split.
+	KVPairs     []box.KV
+	Body        string
 	Footer      string
 	Width       int
 	BorderStyle string
+	Layout      box.KVLayout
+	// Columns is "auto" or a number of KV column groups; it sets Layout.Columns.
+	Columns string
+	// AlignNumbers and Compact set Layout.AlignNumbers and Layout.Compact when not
+	// nil. They are pointers, like Raw, so a layer that doesn't mention them (a flag
+	// not given, a key missing from JSON or a config file) leaves the value of lower
+	// layers in place, while one that does can also turn them off.
+	AlignNumbers *bool
+	Compact      *bool
+	Rules        []string
+	Children     []Child
+	// Locale selects the separators for typed values ("@bytes:", "@number:"...);
+	// empty means English. Children inherit it unless they set their own.
+	Locale string
+	// Raw shows the text fields as written, without inline styling or links; nil
+	// means off. Children inherit it unless they set their own.
+	Raw *bool
+	// Redactor masks secrets once the box is built; nil leaves values untouched.
+	Redactor *redact.Redactor
 }
 
+// Child describes a nested box. It carries its own type because a parent usually
+// groups children of different outcomes (e.g. one box per service).
+type Child struct {
+	Type    string
+	Options Options
+}
+
+// AutoType lets rules and per-row statuses pick the box type instead of the caller.
+const AutoType = "auto"
+
 // ParseBox converts CLI arguments into a validated Box model.
 // This is a pure function that performs all validation upfront (fail-fast)
 // before constructing the box, ensuring that any Box instance that successfully
 // returns from this function is guaranteed to be valid and renderable.
+//
+// Rules are evaluated after KV parsing and can only escalate the requested type.
+// With AutoType the box starts as success and takes the most severe row status
+// or child box type.
+//
+// Redaction runs last, after rules have seen the real values, so a box can still
+// turn red on a value nobody gets to read. It covers children too.
 func ParseBox(boxType string, opts Options) (*box.Box, error) {
-	if err := validate.BoxType(boxType); err != nil {
-		return nil, err
+	if boxType != AutoType {
+		if err := validate.BoxType(boxType); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := validate.BorderStyle(opts.BorderStyle); err != nil {
 		return nil, err
 	}
+	if err := validate.KeyAlign(opts.Layout.KeyAlign); err != nil {
+		return nil, err
+	}
+	layout := opts.Layout
+	if opts.Columns != "" {
+		columns, err := parseColumns(opts.Columns)
+		if err != nil {
+			return nil, err
+		}
+		layout.Columns = columns
+	}
+	if opts.AlignNumbers != nil {
+		layout.AlignNumbers = *opts.AlignNumbers
+	}
+	if opts.Compact != nil {
+		layout.Compact = *opts.Compact
+	}
+	raw := opts.Raw != nil && *opts.Raw
+
+	parsedRules, err := rules.ParseAll(opts.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	locale, err := format.ParseLocale(opts.Locale)
+	if err != nil {
+		return nil, err
+	}
 
 	kvPairs, err := parseKVPairs(opts.KVFlags)
 	if err != nil {
 		return nil, err
 	}
+	for _, kv := range opts.KVPairs {
+		if err := validate.KVPair(kv.String()); err != nil {
+			return nil, err
+		}
+		parsed, err := parseValue(kv)
+		if err != nil {
+			return nil, err
+		}
+		kvPairs = append(kvPairs, parsed)
+	}
+	formatted, err := parseTypedValues(kvPairs, locale, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !raw {
+		stripMarkup(kvPairs, formatted)
+	}
+
+	var children []*box.Box
+	for i, child := range opts.Children {
+		if child.Options.Locale == "" {
+			child.Options.Locale = opts.Locale
+		}
+		if child.Options.Raw == nil {
+			child.Options.Raw = opts.Raw
+		}
+		childBox, err := ParseBox(child.Type, child.Options)
+		if err != nil {
+			return nil, fmt.Errorf("child box %d: %w", i+1, err)
+		}
+		children = append(children, childBox)
+	}
+
+	resolvedType := box.BoxType(boxType)
+	if boxType == AutoType {
+		resolvedType = box.Success
+	}
+	if boxType == AutoType || len(parsedRules) > 0 {
+		resolvedType = rules.Apply(kvPairs, parsedRules, resolvedType)
+	}
+	if boxType == AutoType {
+		for _, child := range children {
+			resolvedType = rules.Worst(resolvedType, child.Type)
+		}
+	}
+	for i, text := range formatted {
+		if text != "" {
+			kvPairs[i].Value = text
+		}
+	}
 
 	b := &box.Box{
-		Type:        box.BoxType(boxType),
+		Type:        resolvedType,
 		Title:       opts.Title,
 		Subtitle:    opts.Subtitle,
 		KVPairs:     kvPairs,
+		Body:        opts.Body,
+		Children:    children,
 		Footer:      opts.Footer,
 		Width:       opts.Width,
 		BorderStyle: opts.BorderStyle,
+		Layout:      layout,
+		Markup:      !raw,
 	}
 
 	if err := validate.Box(b); err != nil {
 		return nil, err
 	}
 
+	if opts.Redactor != nil {
+		b = opts.Redactor.Box(b)
+	}
 	return b, nil
 }
 
-// parseKVPairs converts an array of "key=value" strings into KV structs.
-// Each string is validated before parsing to ensure fail-fast behavior.
+// parseKVPairs converts an array of "key=value" strings into KV structs and
+// interprets their values (statuses, sparklines).
+func parseKVPairs(kvFlags []string) ([]box.KV, error) {
+	split, err := SplitKVFlags(kvFlags)
+	if err != nil || len(split) == 0 {
+		return nil, err
+	}
+
+	kvPairs := make([]box.KV, 0, len(split))
+	for _, kv := range split {
+		parsed, err := parseValue(kv)
+		if err != nil {
+			return nil, err
+		}
+		kvPairs = append(kvPairs, parsed)
+	}
+
+	return kvPairs, nil
+}
+
+// SplitKVFlags splits --kv flags into pairs without interpreting the values, so the
+// result can be passed on as Options.KVPairs. Each pair is validated before parsing
+// to ensure fail-fast behavior.
 // Supports comma-separated pairs (e.g., "A=1,B=2,C=3") for convenience,
 // but only splits on commas that appear before a new key=value pattern.
 // This allows values to contain commas (e.g., "Status=1 staged, 2 modified").
 // Splits on the first '=' only, allowing '=' characters in values
 // (e.g., "url=http://example.com?a=1&b=2").
-func parseKVPairs(kvFlags []string) ([]box.KV, error) {
+func SplitKVFlags(kvFlags []string) ([]box.KV, error) {
 	if len(kvFlags) == 0 {
 		return nil, nil
 	}
@@ -83,17 +240,128 @@ func parseKVPairs(kvFlags []string) ([]box.KV, error) {
 				return nil, err
 			}
 
-			pair := strings.SplitN(kv, "=", 2)
-			kvPairs = append(kvPairs, box.KV{
-				Key:   pair[0],
-				Value: pair[1],
-			})
+			key, value, _ := strings.Cut(kv, "=")
+			kvPairs = append(kvPairs, box.KV{Key: key, Value: value})
 		}
 	}
 
 	return kvPairs, nil
 }
 
+// SparkPrefix marks a value as a numeric series rendered as a sparkline.
+const SparkPrefix = "@spark:"
+
+// StatusSeparator introduces a per-row status suffix, e.g. "db=down!error".
+const StatusSeparator = "!"
+
+// parseValue interprets prefixed values such as "@spark:1,2,3" and status
+// suffixes such as "down!error". Plain values pass through untouched, so only
+// values that opt in with a prefix can fail.
+func parseValue(kv box.KV) (box.KV, error) {
+	kv.Value, kv.Status = splitStatus(kv.Value)
+
+	if !strings.HasPrefix(kv.Value, SparkPrefix) {
+		return kv, nil
+	}
+
+	series, err := parseSeries(strings.TrimPrefix(kv.Value, SparkPrefix))
+	if err != nil {
+		return box.KV{}, fmt.Errorf("invalid sparkline for key %q: %w", kv.Key, err)
+	}
+
+	kv.Series = series
+	kv.Value = strconv.FormatFloat(series[len(series)-1], 'f', -1, 64)
+	return kv, nil
+}
+
+// splitStatus strips a trailing "!<type>" from a value. Only valid box types are
+// recognized, so ordinary exclamation marks ("Done!") are left alone.
+func splitStatus(value string) (string, box.BoxType) {
+	idx := strings.LastIndex(value, StatusSeparator)
+	if idx < 0 {
+		return value, ""
+	}
+
+	status := box.BoxType(value[idx+len(StatusSeparator):])
+	if !status.IsValid() {
+		return value, ""
+	}
+
+	return value[:idx], status
+}
+
+// parseColumns accepts "auto" or a positive number of columns.
+func parseColumns(s string) (int, error) {
+	if s == "auto" {
+		return box.ColumnsAuto, nil
+	}
+	columns, err := strconv.Atoi(s)
+	if err != nil || columns < 1 {
+		return 0, fmt.Errorf("invalid columns %q, must be a positive number or auto", s)
+	}
+	return columns, nil
+}
+
+// parseTypedValues handles "@bytes:", "@duration:", "@number:", "@percent:" and
+// "@ago:" values. Each value is replaced by its canonical number (bytes, seconds,
+// percent) for rules to compare, and the returned display text, indexed like
+// kvPairs and empty for untyped rows, is swapped in once rules have run.
+func parseTypedValues(kvPairs []box.KV, locale format.Locale, now time.Time) ([]string, error) {
+	formatted := make([]string, len(kvPairs))
+	for i, kv := range kvPairs {
+		kind, arg, ok := strings.Cut(kv.Value, ":")
+		kind, isTyped := strings.CutPrefix(kind, "@")
+		if !ok || !isTyped || !format.IsKind(kind) {
+			continue
+		}
+
+		value, err := format.Parse(kind, arg, now)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kv.Key, err)
+		}
+		kvPairs[i].Value = strconv.FormatFloat(value.Number, 'f', -1, 64)
+		formatted[i] = value.Format(locale)
+	}
+	return formatted, nil
+}
+
+// stripMarkup lets rules compare the text the reader sees, so "{red}3{/} failed"
+// still counts as 3. Styled values are kept in formatted, like typed values, and
+// swapped back in once rules have run.
+func stripMarkup(kvPairs []box.KV, formatted []string) {
+	for i, kv := range kvPairs {
+		plain := markup.Plain(markup.Parse(kv.Value))
+		if formatted[i] == "" && plain != kv.Value {
+			kvPairs[i].Value = plain
+			formatted[i] = kv.Value
+		}
+	}
+}
+
+// parseSeries parses a comma-separated list of numbers. At least one sample is
+// required because an empty sparkline has nothing to show.
+func parseSeries(s string) ([]float64, error) {
+	fields := strings.Split(s, ",")
+	series := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(field, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return nil, fmt.Errorf("%q is not a number", field)
+		}
+		series = append(series, n)
+	}
+
+	if len(series) == 0 {
+		return nil, fmt.Errorf("no samples provided")
+	}
+
+	return series, nil
+}
+
 // smartSplitKV splits on commas only when followed by a key=value pattern.
 // This allows values to contain commas while still supporting comma-separated pairs.
 // Examples:
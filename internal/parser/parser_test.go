@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"boxed/internal/box"
+	"boxed/internal/redact"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -69,6 +70,29 @@ func TestParseBox(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "split pairs are not split again",
+			boxType: "info",
+			opts: Options{
+				KVFlags: []string{"env=prod"},
+				KVPairs: []box.KV{{Key: "search", Value: "[q](https://example.com/?a=1,b=2)!warning"}},
+			},
+			want: &box.Box{
+				Type: box.Info,
+				KVPairs: []box.KV{
+					{Key: "env", Value: "prod"},
+					{Key: "search", Value: "[q](https://example.com/?a=1,b=2)", Status: box.Warning},
+				},
+				Markup: true,
+			},
+		},
+		{
+			name:    "split pair without a key",
+			boxType: "info",
+			opts:    Options{KVPairs: []box.KV{{Value: "orphan"}}},
+			wantErr: true,
+			errMsg:  "key cannot be empty",
+		},
 		{
 			name:    "invalid box type",
 			boxType: "invalid",
@@ -103,6 +127,86 @@ func TestParseBox(t *testing.T) {
 			wantErr: true,
 			errMsg:  "no content",
 		},
+		{
+			name:    "rules escalate requested type",
+			boxType: "success",
+			opts: Options{
+				Title:   "System",
+				KVFlags: []string{"CPU=95%"},
+				Rules:   []string{"CPU>90:error"},
+			},
+			want: &box.Box{
+				Type:    box.Error,
+				Title:   "System",
+				KVPairs: []box.KV{{Key: "CPU", Value: "95%", Status: box.Error}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "auto type without matches is success",
+			boxType: AutoType,
+			opts: Options{
+				Title:   "System",
+				KVFlags: []string{"CPU=15%"},
+				Rules:   []string{"CPU>90:error"},
+			},
+			want: &box.Box{
+				Type:    box.Success,
+				Title:   "System",
+				KVPairs: []box.KV{{Key: "CPU", Value: "15%"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "auto type follows row statuses",
+			boxType: AutoType,
+			opts: Options{
+				Title:   "Services",
+				KVFlags: []string{"db=slow!warning"},
+			},
+			want: &box.Box{
+				Type:    box.Warning,
+				Title:   "Services",
+				KVPairs: []box.KV{{Key: "db", Value: "slow", Status: box.Warning}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "auto type follows child boxes",
+			boxType: AutoType,
+			opts: Options{
+				Title: "Release",
+				Children: []Child{
+					{Type: "success", Options: Options{Title: "api"}},
+					{Type: AutoType, Options: Options{Title: "worker", KVFlags: []string{"state=crashloop!error"}}},
+				},
+			},
+			want: &box.Box{
+				Type:  box.Error,
+				Title: "Release",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid child box",
+			boxType: "info",
+			opts: Options{
+				Title:    "Release",
+				Children: []Child{{Type: "bogus", Options: Options{Title: "api"}}},
+			},
+			wantErr: true,
+			errMsg:  "child box 1: invalid box type",
+		},
+		{
+			name:    "invalid rule",
+			boxType: "success",
+			opts: Options{
+				Title: "Test",
+				Rules: []string{"CPU>>90:error"},
+			},
+			wantErr: true,
+			errMsg:  "invalid rule",
+		},
 		{
 			name:    "negative width",
 			boxType: "success",
@@ -178,6 +282,60 @@ func TestParseKVPairs(t *testing.T) {
 			want:    []box.KV{{Key: "url", Value: "http://example.com?a=1&b=2"}},
 			wantErr: false,
 		},
+		{
+			name:    "sparkline series",
+			kvFlags: []string{"Latency=@spark:12,15,11,40,22"},
+			want: []box.KV{
+				{Key: "Latency", Value: "22", Series: []float64{12, 15, 11, 40, 22}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline followed by another pair",
+			kvFlags: []string{"Load=@spark:0.5,1.25,B=2"},
+			want: []box.KV{
+				{Key: "Load", Value: "1.25", Series: []float64{0.5, 1.25}},
+				{Key: "B", Value: "2"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline with non-numeric sample",
+			kvFlags: []string{"Latency=@spark:12,abc"},
+			wantErr: true,
+		},
+		{
+			name:    "empty sparkline",
+			kvFlags: []string{"Latency=@spark:"},
+			wantErr: true,
+		},
+		{
+			name:    "status suffix",
+			kvFlags: []string{"db=down!error", "cache=ok!success"},
+			want: []box.KV{
+				{Key: "db", Value: "down", Status: box.Error},
+				{Key: "cache", Value: "ok", Status: box.Success},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "exclamation mark that is not a status",
+			kvFlags: []string{"msg=Done!", "alert=disk!full", "job=queued!pending"},
+			want: []box.KV{
+				{Key: "msg", Value: "Done!"},
+				{Key: "alert", Value: "disk!full"},
+				{Key: "job", Value: "queued!pending"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "sparkline with status",
+			kvFlags: []string{"Latency=@spark:1,9!warning"},
+			want: []box.KV{
+				{Key: "Latency", Value: "9", Series: []float64{1, 9}, Status: box.Warning},
+			},
+			wantErr: false,
+		},
 		{
 			name:    "invalid format",
 			kvFlags: []string{"invalid"},
@@ -203,3 +361,127 @@ func TestParseKVPairs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBoxRedaction(t *testing.T) {
+	redactor, err := redact.New(nil)
+	require.NoError(t, err)
+
+	opts := Options{
+		Title:    "Deploy",
+		KVFlags:  []string{"DB_PASSWORD=hunter2", "Errors=3"},
+		Rules:    []string{"DB_PASSWORD~hunter:error"},
+		Body:     "connecting to postgres://app:s3cret@db:5432/app",
+		Redactor: redactor,
+		Children: []Child{{Type: "info", Options: Options{KVFlags: []string{"token=abc"}}}},
+	}
+
+	b, err := ParseBox("success", opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, box.Error, b.Type, "rules see the value before it is masked")
+	assert.Equal(t, redact.Mask, b.KVPairs[0].Value)
+	assert.Equal(t, "3", b.KVPairs[1].Value)
+	assert.Equal(t, "connecting to postgres://app:"+redact.Mask+"@db:5432/app", b.Body)
+	assert.Equal(t, redact.Mask, b.Children[0].KVPairs[0].Value)
+}
+
+func TestParseBoxTypedValues(t *testing.T) {
+	opts := Options{
+		KVFlags:  []string{"Size=@bytes:2576980377", "Duration=@duration:154s!warning", "Files=@number:12345", "Note=@later"},
+		Rules:    []string{"Size>2000000000:error"},
+		Locale:   "de",
+		Children: []Child{{Type: "info", Options: Options{KVFlags: []string{"Rows=@number:1234"}}}},
+	}
+
+	b, err := ParseBox(AutoType, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, box.Error, b.Type, "rules compare the number of bytes, not the display text")
+	assert.Equal(t, []box.KV{
+		{Key: "Size", Value: "2,4 GiB", Status: box.Error},
+		{Key: "Duration", Value: "2m 34s", Status: box.Warning},
+		{Key: "Files", Value: "12.345"},
+		{Key: "Note", Value: "@later"},
+	}, b.KVPairs)
+	assert.Equal(t, "1.234", b.Children[0].KVPairs[0].Value, "children inherit the locale")
+
+	_, err = ParseBox("info", Options{KVFlags: []string{"Size=@bytes:2GB"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `key "Size": invalid bytes value "2GB"`)
+
+	_, err = ParseBox("info", Options{Locale: "klingon"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported locale "klingon"`)
+}
+
+func TestParseBoxMarkup(t *testing.T) {
+	opts := Options{
+		KVFlags:  []string{"Failed={red}**3**{/} of 120"},
+		Rules:    []string{"Failed>0:error"},
+		Children: []Child{{Type: "info", Options: Options{Title: "**db**"}}},
+	}
+
+	b, err := ParseBox("success", opts)
+	require.NoError(t, err)
+	assert.Equal(t, box.Error, b.Type, "rules compare the text without markup")
+	assert.Equal(t, "{red}**3**{/} of 120", b.KVPairs[0].Value)
+	assert.True(t, b.Markup)
+	assert.True(t, b.Children[0].Markup)
+
+	raw, styled := true, false
+	opts.Raw = &raw
+	b, err = ParseBox("success", opts)
+	require.NoError(t, err)
+	assert.Equal(t, box.Success, b.Type, "raw values are compared as written")
+	assert.False(t, b.Markup)
+	assert.False(t, b.Children[0].Markup, "children inherit raw")
+
+	opts.Children[0].Options.Raw = &styled
+	b, err = ParseBox("success", opts)
+	require.NoError(t, err)
+	assert.True(t, b.Children[0].Markup, "a child can set its own")
+}
+
+func TestParseBoxLayoutSwitches(t *testing.T) {
+	on, off := true, false
+	layout := box.KVLayout{AlignNumbers: true, Compact: true}
+
+	b, err := ParseBox("info", Options{Title: "Env", Layout: layout})
+	require.NoError(t, err)
+	assert.Equal(t, layout, b.Layout, "unset switches keep the layout")
+
+	b, err = ParseBox("info", Options{Title: "Env", Layout: layout, AlignNumbers: &off, Compact: &off})
+	require.NoError(t, err)
+	assert.Equal(t, box.KVLayout{}, b.Layout)
+
+	b, err = ParseBox("info", Options{Title: "Env", Compact: &on})
+	require.NoError(t, err)
+	assert.Equal(t, box.KVLayout{Compact: true}, b.Layout)
+}
+
+func TestParseBoxColumns(t *testing.T) {
+	tests := []struct {
+		columns string
+		want    int
+		wantErr bool
+	}{
+		{columns: "", want: 0},
+		{columns: "3", want: 3},
+		{columns: "auto", want: box.ColumnsAuto},
+		{columns: "0", wantErr: true},
+		{columns: "many", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.columns, func(t *testing.T) {
+			b, err := ParseBox("info", Options{Title: "Env", Columns: tt.columns})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "must be a positive number or auto")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, b.Layout.Columns)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"boxed/internal/box"
+)
+
+// Mask replaces redacted text. It has a fixed length so it doesn't reveal how long
+// the secret was.
+const Mask = "••••••"
+
+// sensitiveWords are the words that mark a KV key as holding a secret. Keys are
+// split into words first, so "DB_PASSWORD", "apiKey" and "Client Secret" match
+// while "Monkey" and "Tokens" (a count) don't.
+var sensitiveWords = map[string]bool{
+	"password":    true,
+	"passwd":      true,
+	"pwd":         true,
+	"passphrase":  true,
+	"secret":      true,
+	"token":       true,
+	"key":         true,
+	"apikey":      true,
+	"credential":  true,
+	"credentials": true,
+}
+
+// builtinPatterns find credentials in free text. Each replacement keeps the
+// surrounding context (the URL, the assignment) so the output stays readable.
+var builtinPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// user:pass@ in URLs, including connection strings like postgres://...
+	{regexp.MustCompile(`(?i)\b([a-z][a-z0-9+.-]*://[^/\s:@]+:)([^/\s@]+)(@)`), "${1}" + Mask + "${3}"},
+	// Assignments such as DB_PASSWORD=..., "token": "...", ?access_token=... The
+	// keyword has to start a word so "monkey=1" is left alone, and an unquoted value
+	// ends at ")" or "]" so a markup link such as [ci](https://ci/x?token=...) stays
+	// a link.
+	{regexp.MustCompile(`(?i)\b((?:[\w.-]*[_.-])?(?:password|passwd|pwd|passphrase|secret|token|api[_-]?key|access[_-]?key|secret[_-]?key|private[_-]?key|access[_-]?token|auth[_-]?token|refresh[_-]?token|client[_-]?secret)(?:[_.-][\w.-]*)?"?)(\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s;&,"')\]]+)`), "${1}${2}" + Mask},
+	{regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9._~+/-]+=*`), "${1}" + Mask},
+	// JSON Web Tokens: three base64url segments, the first always starting with {"
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), Mask},
+	// AWS access key IDs
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), Mask},
+	// GitHub, Slack and Stripe tokens
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`), Mask},
+	{regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), Mask},
+	{regexp.MustCompile(`\b[sr]k_(?:live|test)_[A-Za-z0-9]{16,}\b`), Mask},
+	{regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`), Mask},
+}
+
+// Redactor masks secrets in boxes before they're rendered.
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// New creates a redactor with the built-in rules plus user patterns. A pattern
+// with capture groups only masks the groups, so `password=(\S+)` keeps the key.
+func New(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// InCI reports whether we're running in a CI environment, where logs are kept and
+// often visible to more people than the ones who wrote the script. Most CI systems
+// set CI; the others are checked by their own variables.
+func InCI(getenv func(string) string) bool {
+	if ci := strings.ToLower(getenv("CI")); ci != "" && ci != "false" && ci != "0" {
+		return true
+	}
+	for _, name := range []string{"GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "JENKINS_URL", "TF_BUILD", "TEAMCITY_VERSION"} {
+		if getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// SensitiveKey reports whether a KV key names a secret.
+func SensitiveKey(key string) bool {
+	for _, word := range splitWords(key) {
+		if sensitiveWords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// splitWords splits on anything that isn't a letter or digit and on lower-to-upper
+// case changes, returning lowercase words: "awsSecretKey" → aws, secret, key.
+func splitWords(s string) []string {
+	var words []string
+	var current []rune
+	var prev rune
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+	for _, r := range s {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+		prev = r
+	}
+	flush()
+	return words
+}
+
+// Text masks credentials found in free text.
+func (r *Redactor) Text(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, builtin := range builtinPatterns {
+		s = builtin.pattern.ReplaceAllString(s, builtin.replacement)
+	}
+	for _, re := range r.patterns {
+		s = maskMatches(re, s)
+	}
+	return s
+}
+
+// maskMatches replaces each match of re, or only its capture groups when it has any.
+func maskMatches(re *regexp.Regexp, s string) string {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllLiteralString(s, Mask)
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringSubmatchIndex(s, -1) {
+		for group := 1; group <= re.NumSubexp(); group++ {
+			start, end := match[2*group], match[2*group+1]
+			// Skip groups that didn't participate or are nested in one already masked.
+			if start < 0 || start < last {
+				continue
+			}
+			out.WriteString(s[last:start])
+			out.WriteString(Mask)
+			last = end
+		}
+	}
+	out.WriteString(s[last:])
+	return out.String()
+}
+
+// Box returns a copy of a box and its children with secrets masked. Values of
+// sensitive keys are masked entirely (sparkline samples included); everything else
+// only has recognized credentials replaced. b itself is left alone, since live
+// commands keep the data they render and build the next frame from it.
+func (r *Redactor) Box(b *box.Box) *box.Box {
+	masked := *b
+	masked.Title = r.Text(b.Title)
+	masked.Subtitle = r.Text(b.Subtitle)
+	masked.Body = r.Text(b.Body)
+	masked.Footer = r.Text(b.Footer)
+
+	masked.KVPairs = make([]box.KV, len(b.KVPairs))
+	for i, kv := range b.KVPairs {
+		if SensitiveKey(kv.Key) && kv.Value != "" {
+			kv.Value = Mask
+			kv.Series = nil
+		} else {
+			kv.Value = r.Text(kv.Value)
+		}
+		masked.KVPairs[i] = kv
+	}
+
+	masked.Children = make([]*box.Box, len(b.Children))
+	for i, child := range b.Children {
+		masked.Children[i] = r.Box(child)
+	}
+	return &masked
+}
@@ -38,11 +38,60 @@ func (r *LipGlossRenderer) getColorForType(t box.BoxType) string {
 		return "111"
 	case box.Warning:
 		return "179"
+	case box.Pending:
+		return "141"
 	default:
 		return "7"
 	}
 }
 
+// getInlineColor resolves the name of a markup color span. Box type names use the
+// type colors so "{error}3 failed{/}" matches an error box, and the plain names pick
+// from the same soft palette; hex colors are passed through.
+func getInlineColor(name string) string {
+	switch name {
+	case "success", "green":
+		return "114"
+	case "error", "red":
+		return "210"
+	case "info", "blue":
+		return "111"
+	case "warning", "yellow":
+		return "179"
+	case "pending", "magenta":
+		return "141"
+	case "cyan":
+		return "117"
+	case "orange":
+		return "215"
+	case "gray":
+		return "245"
+	case "white":
+		return "255"
+	default:
+		return name
+	}
+}
+
+// getIconForType uses the same single-cell symbols as common CLI logging libraries so
+// per-row statuses line up regardless of which icon a row carries.
+func getIconForType(t box.BoxType) string {
+	switch t {
+	case box.Success:
+		return "✔"
+	case box.Error:
+		return "✖"
+	case box.Info:
+		return "ℹ"
+	case box.Warning:
+		return "⚠"
+	case box.Pending:
+		return "…"
+	default:
+		return "•"
+	}
+}
+
 // getGradientForType defines color progressions that transition between related hues
 // rather than brightness levels. This aesthetic choice emerged from user feedback that
 // dark-to-bright gradients felt too dramatic. Gradient arrays are intentionally sparse
@@ -57,6 +106,8 @@ func (r *LipGlossRenderer) getGradientForType(t box.BoxType) []string {
 		return []string{"111", "117", "153", "189", "225", "219", "213", "177"}
 	case box.Warning:
 		return []string{"179", "215", "221", "227", "228", "229", "223", "217"}
+	case box.Pending:
+		return []string{"141", "147", "183", "189", "153", "117", "111", "105"}
 	default:
 		return []string{"238", "240", "242", "244", "246", "248", "250"}
 	}
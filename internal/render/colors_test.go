@@ -19,6 +19,7 @@ func TestLipGlossRenderer_GetColorForType(t *testing.T) {
 		{box.Error, "210"},
 		{box.Info, "111"},
 		{box.Warning, "179"},
+		{box.Pending, "141"},
 	}
 
 	for _, tt := range tests {
@@ -65,34 +65,81 @@ func buildHeaderLine(border lipgloss.Border, text string, width int, gradient []
 	return buildSideBorders(border, width, sideColor, sideColor, content)
 }
 
-// buildFooterLine uses gray (color 240) for all slashes rather than the gradient colors,
+// footerStyle is gray (color 240) for the footer text and slashes.
+var footerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+// buildFooterLine uses gray for all slashes rather than the gradient colors,
 // creating visual hierarchy where the header is prominent and the footer is subdued.
 // This design choice helps users focus on the header (typically status/title) while
-// keeping footer metadata available but not dominant.
+// keeping footer metadata available but not dominant. The text comes styled by the
+// caller, normally in footerStyle, so inline markup can style parts of it.
 func buildFooterLine(border lipgloss.Border, text string, width int, sideColor string) string {
 	if text == "" {
 		return ""
 	}
 
-	grayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
-
 	prefix := "╱╱ "
 	suffix := " "
-	textWithPadding := prefix + text + suffix
-
-	textWidth := lipgloss.Width(textWithPadding)
+	textWidth := lipgloss.Width(prefix + text + suffix)
 	totalWidth := width + contentPadding*2
 
 	if textWidth >= totalWidth {
-		truncated := truncateText(text, totalWidth-lipgloss.Width(prefix+suffix))
-		textWithPadding = prefix + truncated + suffix
-		textWidth = lipgloss.Width(textWithPadding)
+		text = truncateText(text, totalWidth-lipgloss.Width(prefix+suffix))
+		textWidth = lipgloss.Width(prefix + text + suffix)
 	}
 
 	slashCount := totalWidth - textWidth
 	slashes := strings.Repeat("╱", slashCount)
 
-	content := grayStyle.Render(textWithPadding + slashes)
+	content := footerStyle.Render(prefix) + text + footerStyle.Render(suffix+slashes)
 
 	return buildSideBorders(border, width, sideColor, sideColor, content)
 }
+
+// sparkLevels are the eighth-block glyphs used for sparklines, lowest to highest.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// buildSparkline scales the series between its own min and max rather than from zero,
+// because metrics like latency rarely start at zero and small variations would otherwise
+// flatten into a single glyph. Each glyph takes its color from the horizontal gradient,
+// matching the header slashes. When the series doesn't fit, the oldest samples are dropped
+// since the most recent ones are what status boxes care about.
+func buildSparkline(series []float64, latest string, maxWidth int, gradient []string) string {
+	sparkWidth := maxWidth - lipgloss.Width(latest) - 1
+	if sparkWidth < 1 {
+		sparkWidth = 1
+	}
+	if len(series) > sparkWidth {
+		series = series[len(series)-sparkWidth:]
+	}
+
+	lowest, highest := series[0], series[0]
+	for _, v := range series {
+		if v < lowest {
+			lowest = v
+		}
+		if v > highest {
+			highest = v
+		}
+	}
+
+	var spark strings.Builder
+	for i, v := range series {
+		level := 0
+		if highest > lowest {
+			level = int((v - lowest) / (highest - lowest) * float64(len(sparkLevels)-1))
+		}
+
+		percentage := 0.0
+		if len(series) > 1 {
+			percentage = float64(i) / float64(len(series)-1)
+		}
+		colorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(getGradientColorAt(gradient, percentage)))
+		spark.WriteString(colorStyle.Render(string(sparkLevels[level])))
+	}
+
+	if latest == "" {
+		return spark.String()
+	}
+	return spark.String() + " " + latest
+}
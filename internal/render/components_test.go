@@ -1,4 +1,52 @@
 package render
 
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
 // Tests for border, header, and footer components would go here.
 // Currently tested via integration tests in render_test.go.
+
+func TestBuildSparkline(t *testing.T) {
+	gradient := []string{"114", "120"}
+
+	tests := []struct {
+		name     string
+		series   []float64
+		latest   string
+		maxWidth int
+		want     string
+	}{
+		{
+			name:     "scales between min and max",
+			series:   []float64{1, 8, 4.5},
+			latest:   "4.5",
+			maxWidth: 20,
+			want:     "▁█▄ 4.5",
+		},
+		{
+			name:     "flat series uses lowest glyph",
+			series:   []float64{5, 5, 5},
+			latest:   "5",
+			maxWidth: 20,
+			want:     "▁▁▁ 5",
+		},
+		{
+			name:     "drops oldest samples when too wide",
+			series:   []float64{100, 1, 2, 3},
+			latest:   "3",
+			maxWidth: 5,
+			want:     "▁▄█ 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSparkline(tt.series, tt.latest, tt.maxWidth, gradient)
+			assert.Equal(t, tt.want, ansi.Strip(got))
+		})
+	}
+}
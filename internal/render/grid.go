@@ -0,0 +1,104 @@
+package render
+
+import (
+	"strings"
+
+	"boxed/internal/box"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// GridLayout controls how RenderGrid arranges boxes. Columns is an upper bound:
+// when MaxWidth is set and the requested columns don't fit, boxes wrap onto more
+// rows instead of overflowing the terminal. Columns <= 0 means "as many as fit".
+type GridLayout struct {
+	Columns  int
+	MaxWidth int
+	Gap      int
+}
+
+// RenderGrid renders boxes in rows with a shared width and equal heights per row.
+// Widths are shared across the whole grid (not per column) so a dashboard reads as
+// a uniform set of tiles; heights are equalized per row only, since padding every box
+// to the tallest one in the grid wastes vertical space. The input boxes are not modified.
+func RenderGrid(r Renderer, boxes []*box.Box, layout GridLayout) string {
+	if len(boxes) == 0 {
+		return ""
+	}
+
+	cellWidth := 0
+	for _, b := range boxes {
+		lines := r.RenderLines(b)
+		if len(lines) > 0 && lipgloss.Width(lines[0]) > cellWidth {
+			cellWidth = lipgloss.Width(lines[0])
+		}
+	}
+	contentWidth := cellWidth - FrameWidth
+
+	columns := gridColumns(layout, cellWidth, len(boxes))
+	gap := strings.Repeat(" ", max(layout.Gap, 0))
+
+	var rows []string
+	for start := 0; start < len(boxes); start += columns {
+		end := min(start+columns, len(boxes))
+		rows = append(rows, renderGridRow(r, boxes[start:end], contentWidth, gap))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// gridColumns picks the effective column count from the layout and the space available.
+// A negative gap counts as none, as it does for the padding between boxes.
+func gridColumns(layout GridLayout, cellWidth, boxCount int) int {
+	columns := layout.Columns
+	if columns <= 0 || columns > boxCount {
+		columns = boxCount
+	}
+
+	if layout.MaxWidth > 0 {
+		gap := max(layout.Gap, 0)
+		fit := (layout.MaxWidth + gap) / max(cellWidth+gap, 1)
+		if fit < 1 {
+			fit = 1
+		}
+		if columns > fit {
+			columns = fit
+		}
+	}
+
+	return columns
+}
+
+// renderGridRow renders boxes twice: once to find the tallest one, then again with
+// Height set so shorter boxes grow inside their borders instead of leaving ragged gaps.
+func renderGridRow(r Renderer, boxes []*box.Box, contentWidth int, gap string) string {
+	cells := make([][]string, len(boxes))
+	sized := make([]box.Box, len(boxes))
+	rowHeight := 0
+	for i, b := range boxes {
+		sized[i] = *b
+		sized[i].Width = contentWidth
+		cells[i] = r.RenderLines(&sized[i])
+		rowHeight = max(rowHeight, len(cells[i]))
+	}
+
+	for i := range sized {
+		if len(cells[i]) < rowHeight {
+			sized[i].Height = rowHeight
+			cells[i] = r.RenderLines(&sized[i])
+		}
+	}
+
+	lines := make([]string, rowHeight)
+	for lineIndex := range lines {
+		parts := make([]string, len(cells))
+		for i, cell := range cells {
+			if lineIndex < len(cell) {
+				parts[i] = cell[lineIndex]
+			}
+		}
+		lines[lineIndex] = strings.Join(parts, gap)
+	}
+
+	return strings.Join(lines, "\n")
+}
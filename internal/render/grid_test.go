@@ -0,0 +1,75 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLipGlossRenderer_RenderLinesHeight(t *testing.T) {
+	renderer := NewLipGlossRenderer()
+	b := &box.Box{Type: box.Info, Title: "Status", Footer: "done"}
+
+	natural := renderer.RenderLines(b)
+	padded := renderer.RenderLines(&box.Box{Type: box.Info, Title: "Status", Footer: "done", Height: len(natural) + 3})
+
+	assert.Len(t, padded, len(natural)+3)
+	assert.Contains(t, padded[len(padded)-2], "done", "footer stays at the bottom")
+	assert.Equal(t, strings.Join(natural, "\n"), renderer.RenderBox(b))
+}
+
+func TestRenderGrid(t *testing.T) {
+	renderer := NewLipGlossRenderer()
+	boxes := []*box.Box{
+		{Type: box.Success, Title: "API"},
+		{Type: box.Error, Title: "Database", KVPairs: []box.KV{{Key: "conn", Value: "refused"}, {Key: "host", Value: "db-1"}}},
+		{Type: box.Info, Title: "Cache"},
+	}
+
+	output := RenderGrid(renderer, boxes, GridLayout{Columns: 2, Gap: 1})
+	lines := strings.Split(output, "\n")
+
+	firstRowHeight := len(renderer.RenderLines(boxes[1]))
+	require.Greater(t, len(lines), firstRowHeight)
+
+	cellWidth := lipgloss.Width(renderer.RenderLines(boxes[1])[0])
+	for _, line := range lines[:firstRowHeight] {
+		assert.Equal(t, cellWidth*2+1, lipgloss.Width(line), "first row holds two equal-height boxes")
+	}
+	assert.Equal(t, cellWidth, lipgloss.Width(lines[firstRowHeight]), "third box wraps to a new row at the shared width")
+	assert.Contains(t, output, "Cache")
+	assert.Equal(t, box.Success, boxes[0].Type)
+	assert.Zero(t, boxes[0].Width, "input boxes are not modified")
+}
+
+func TestGridColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout GridLayout
+		boxes  int
+		want   int
+	}{
+		{"requested columns", GridLayout{Columns: 2}, 5, 2},
+		{"unbounded uses all boxes", GridLayout{}, 3, 3},
+		{"capped at box count", GridLayout{Columns: 4}, 2, 2},
+		{"wraps to fit width", GridLayout{Columns: 3, MaxWidth: 85, Gap: 1}, 3, 2},
+		{"auto fits width", GridLayout{MaxWidth: 130, Gap: 2}, 6, 3},
+		{"always at least one", GridLayout{Columns: 3, MaxWidth: 10}, 3, 1},
+		{"negative gap counts as none", GridLayout{MaxWidth: 85, Gap: -40}, 3, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gridColumns(tt.layout, 40, tt.boxes))
+		})
+	}
+}
+
+func TestRenderGrid_Empty(t *testing.T) {
+	assert.Empty(t, RenderGrid(NewLipGlossRenderer(), nil, GridLayout{}))
+}
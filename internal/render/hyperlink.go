@@ -0,0 +1,54 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+// hyperlinkPrograms are $TERM_PROGRAM values of terminals known to support OSC 8.
+var hyperlinkPrograms = map[string]bool{
+	"iTerm.app": true,
+	"WezTerm":   true,
+	"vscode":    true,
+	"ghostty":   true,
+	"Hyper":     true,
+	"Tabby":     true,
+}
+
+// hyperlinkTerms are $TERM values of terminals known to support OSC 8.
+var hyperlinkTerms = map[string]bool{
+	"xterm-kitty":   true,
+	"xterm-ghostty": true,
+	"alacritty":     true,
+	"foot":          true,
+	"wezterm":       true,
+}
+
+// SupportsHyperlinks guesses whether the terminal shows OSC 8 hyperlinks. There's
+// no way to ask, and terminals that don't support them may print the URL as garbage,
+// so only known terminals qualify. FORCE_HYPERLINK overrides the guess either way,
+// as it does for other tools.
+func SupportsHyperlinks(getenv func(string) string, isTerminal bool) bool {
+	if force := getenv("FORCE_HYPERLINK"); force != "" {
+		return force != "0" && !strings.EqualFold(force, "false")
+	}
+	if !isTerminal || getenv("TERM") == "dumb" {
+		return false
+	}
+
+	if hyperlinkPrograms[getenv("TERM_PROGRAM")] || hyperlinkTerms[getenv("TERM")] {
+		return true
+	}
+	// Windows Terminal, Konsole and kitty set their own variables, which survive
+	// ssh and sudo less often than TERM but are more specific.
+	for _, name := range []string{"WT_SESSION", "KONSOLE_VERSION", "KITTY_WINDOW_ID"} {
+		if getenv(name) != "" {
+			return true
+		}
+	}
+	// GNOME Terminal, Tilix and other VTE terminals since 0.50.
+	if vte, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsHyperlinks(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        map[string]string
+		isTerminal bool
+		want       bool
+	}{
+		{name: "known terminal program", env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, isTerminal: true, want: true},
+		{name: "known TERM", env: map[string]string{"TERM": "xterm-kitty"}, isTerminal: true, want: true},
+		{name: "recent VTE", env: map[string]string{"VTE_VERSION": "7201"}, isTerminal: true, want: true},
+		{name: "old VTE", env: map[string]string{"VTE_VERSION": "4601"}, isTerminal: true, want: false},
+		{name: "unknown terminal", env: map[string]string{"TERM": "xterm-256color"}, isTerminal: true, want: false},
+		{name: "not a terminal", env: map[string]string{"TERM_PROGRAM": "WezTerm"}, isTerminal: false, want: false},
+		{name: "forced on", env: map[string]string{"FORCE_HYPERLINK": "1"}, isTerminal: false, want: true},
+		{name: "forced off", env: map[string]string{"FORCE_HYPERLINK": "0", "TERM_PROGRAM": "WezTerm"}, isTerminal: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SupportsHyperlinks(func(key string) string { return tt.env[key] }, tt.isTerminal)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
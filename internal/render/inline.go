@@ -0,0 +1,157 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+
+	"boxed/internal/markup"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// inline renders text fields that may contain markup (see markup.Parse). Wrapping and truncation work
+// on the visible text; spans are styled afterwards, one line at a time, so no escape
+// sequence is left open across a line break where it would leak into the border.
+type inline struct {
+	// markup is off for boxes built from collected data, whose text is shown as-is.
+	markup     bool
+	hyperlinks bool
+}
+
+// spans returns what to display: links fall back to "text (url)" when the
+// terminal can't show hyperlinks.
+func (in inline) spans(text string) []markup.Span {
+	if !in.markup {
+		return []markup.Span{{Text: text}}
+	}
+	spans := markup.Parse(text)
+	if !in.hyperlinks {
+		spans = markup.WithoutLinks(spans)
+	}
+	return spans
+}
+
+// visible returns the text as it appears on screen, for measuring and alignment.
+func (in inline) visible(text string) string {
+	return markup.Plain(in.spans(text))
+}
+
+// line renders text on a single line.
+func (in inline) line(text string, base lipgloss.Style) string {
+	return strings.Join(in.lines(text, base, nil), "\n")
+}
+
+// lines lays out text with fit (e.g. wrapText), which may only insert line breaks,
+// collapse whitespace and append characters such as an ellipsis. Each line is styled
+// with base plus the formatting of the span every character came from; characters
+// fit added get base only.
+func (in inline) lines(text string, base lipgloss.Style, fit func(string) string) []string {
+	spans := in.spans(text)
+	plain := []rune(markup.Plain(spans))
+	owners := make([]int, 0, len(plain))
+	for i, span := range spans {
+		// Unformatted text gets base only, like the characters fit adds, so it
+		// doesn't split into runs around spaces.
+		owner := i
+		if span == (markup.Span{Text: span.Text}) {
+			owner = -1
+		}
+		for range span.Text {
+			owners = append(owners, owner)
+		}
+	}
+
+	fitted := string(plain)
+	if fit != nil {
+		fitted = fit(fitted)
+	}
+
+	var lines []string
+	var line, run strings.Builder
+	runSpan := -1
+	flush := func() {
+		if run.Len() > 0 {
+			line.WriteString(in.render(spans, runSpan, run.String(), base))
+			run.Reset()
+		}
+	}
+
+	// p walks plain alongside fitted; last is the span of the previous character.
+	p, last := 0, -1
+	for _, r := range fitted {
+		if r == '\n' {
+			flush()
+			lines = append(lines, line.String())
+			line.Reset()
+			continue
+		}
+
+		owner := -1
+		if unicode.IsSpace(r) {
+			if p < len(plain) && unicode.IsSpace(plain[p]) {
+				p++
+			}
+			// A space inside a span keeps its style, so "**two words**" is one run.
+			next := p
+			for next < len(plain) && unicode.IsSpace(plain[next]) {
+				next++
+			}
+			if next < len(plain) && owners[next] == last {
+				owner = last
+			}
+		} else {
+			for p < len(plain) && unicode.IsSpace(plain[p]) {
+				p++
+			}
+			if p < len(plain) && plain[p] == r {
+				owner = owners[p]
+				p++
+			}
+			last = owner
+		}
+
+		if owner != runSpan {
+			flush()
+			runSpan = owner
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return append(lines, line.String())
+}
+
+// codeStyle sets code apart with a background, leaving the color to the field.
+var codeStyle = lipgloss.NewStyle().Background(lipgloss.Color("237"))
+
+// render styles one run of characters from the same span (-1 for none). Span styles
+// are added to base rather than nested inside it, because a nested reset would end
+// base's color halfway through the field.
+func (in inline) render(spans []markup.Span, index int, text string, base lipgloss.Style) string {
+	if index < 0 {
+		return base.Render(text)
+	}
+	span := spans[index]
+	style := base
+	if span.Bold {
+		style = style.Bold(true)
+	}
+	if span.Italic {
+		style = style.Italic(true)
+	}
+	if span.Strike {
+		style = style.Strikethrough(true)
+	}
+	if span.Code {
+		style = style.Inherit(codeStyle)
+	}
+	if span.Color != "" {
+		style = style.Foreground(lipgloss.Color(getInlineColor(span.Color)))
+	}
+
+	styled := style.Render(text)
+	if span.URL != "" {
+		styled = ansi.SetHyperlink(span.URL) + styled + ansi.ResetHyperlink()
+	}
+	return styled
+}
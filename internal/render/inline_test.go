@@ -0,0 +1,103 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInlineLines(t *testing.T) {
+	const url = "https://ci.example.com/jobs/1234567890"
+	link := func(text string) string {
+		return ansi.SetHyperlink(url) + text + ansi.ResetHyperlink()
+	}
+
+	tests := []struct {
+		name  string
+		in    inline
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "hyperlink shows only its text",
+			in:    inline{markup: true, hyperlinks: true},
+			text:  "job [build 42](" + url + ") failed",
+			width: 100,
+			want:  []string{"job " + link("build 42") + " failed"},
+		},
+		{
+			name:  "wrapping closes the hyperlink on every line",
+			in:    inline{markup: true, hyperlinks: true},
+			text:  "job [build number 42](" + url + ")",
+			width: 12,
+			want:  []string{"job " + link("build"), link("number 42")},
+		},
+		{
+			name:  "fallback shows the URL",
+			in:    inline{markup: true},
+			text:  "[build 42](" + url + ")",
+			width: 100,
+			want:  []string{"build 42 (" + url + ")"},
+		},
+		{
+			name:  "markup off",
+			in:    inline{hyperlinks: true},
+			text:  "[build 42](" + url + ")",
+			width: 100,
+			want:  []string{"[build 42](" + url + ")"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := tt.in.lines(tt.text, lipgloss.NewStyle(), func(s string) string { return wrapText(s, tt.width) })
+
+			assert.Equal(t, tt.want, lines)
+			for _, line := range lines {
+				assert.LessOrEqual(t, lipgloss.Width(line), tt.width)
+			}
+		})
+	}
+}
+
+func TestInlineVisible(t *testing.T) {
+	text := "PR [#123](https://example.com/pull/123)"
+
+	assert.Equal(t, "PR #123", inline{markup: true, hyperlinks: true}.visible(text))
+	assert.Equal(t, "PR #123 (https://example.com/pull/123)", inline{markup: true}.visible(text))
+	assert.Equal(t, 7, lipgloss.Width(inline{markup: true, hyperlinks: true}.line(text, lipgloss.NewStyle())))
+}
+
+func TestInlineStyles(t *testing.T) {
+	base := lipgloss.NewStyle().Foreground(lipgloss.Color("114"))
+	bold := base.Bold(true)
+	red := base.Foreground(lipgloss.Color("210"))
+
+	t.Run("spans add to the field style", func(t *testing.T) {
+		line := inline{markup: true}.line("**3** {error}failed{/} of 120", base)
+
+		assert.Equal(t, bold.Render("3")+base.Render(" ")+red.Render("failed")+base.Render(" of 120"), line)
+		assert.Equal(t, 15, lipgloss.Width(line))
+	})
+
+	t.Run("wrapped styles are closed on every line", func(t *testing.T) {
+		lines := inline{markup: true}.lines("**all tests passed**", base, func(s string) string { return wrapText(s, 10) })
+
+		assert.Equal(t, []string{bold.Render("all tests"), bold.Render("passed")}, lines)
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		line := inline{markup: true}.line("`make release-candidate`", base)
+		fitted := inline{markup: true}.lines("`make release-candidate`", base, func(s string) string { return truncateText(s, 10) })
+
+		assert.Equal(t, 22, lipgloss.Width(line))
+		assert.Equal(t, 10, lipgloss.Width(fitted[0]))
+	})
+
+	t.Run("markup off", func(t *testing.T) {
+		assert.Equal(t, base.Render("**3** failed"), inline{}.line("**3** failed", base))
+	})
+}
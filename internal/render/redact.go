@@ -0,0 +1,33 @@
+package render
+
+import (
+	"boxed/internal/box"
+	"boxed/internal/redact"
+)
+
+// RedactingRenderer masks secrets in every box before the wrapped renderer draws it.
+// parser.ParseBox already masks the boxes it builds; this covers the ones that report
+// readers and live updates build directly. Masking a masked box changes nothing, so
+// parsed boxes can pass through it too.
+type RedactingRenderer struct {
+	renderer Renderer
+	redactor *redact.Redactor
+}
+
+// NewRedactingRenderer wraps renderer so boxes are masked by redactor first.
+func NewRedactingRenderer(renderer Renderer, redactor *redact.Redactor) *RedactingRenderer {
+	return &RedactingRenderer{renderer: renderer, redactor: redactor}
+}
+
+func (r *RedactingRenderer) RenderBox(b *box.Box) string {
+	return r.renderer.RenderBox(r.redactor.Box(b))
+}
+
+func (r *RedactingRenderer) RenderLines(b *box.Box) []string {
+	return r.renderer.RenderLines(r.redactor.Box(b))
+}
+
+// RenderLinesWithKVs returns the masked pairs, so the viewer copies what it shows.
+func (r *RedactingRenderer) RenderLinesWithKVs(b *box.Box) ([]string, [][]box.KV) {
+	return r.renderer.RenderLinesWithKVs(r.redactor.Box(b))
+}
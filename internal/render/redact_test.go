@@ -0,0 +1,35 @@
+package render
+
+import (
+	"testing"
+
+	"boxed/internal/box"
+	"boxed/internal/redact"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactingRenderer(t *testing.T) {
+	redactor, err := redact.New(nil)
+	require.NoError(t, err)
+
+	mock := &mockRenderer{}
+	r := NewRedactingRenderer(mock, redactor)
+	b := &box.Box{
+		Type:    box.Error,
+		Title:   "postgres://app:s3cret@db:5432/app",
+		KVPairs: []box.KV{{Key: "DB_PASSWORD", Value: "hunter2"}, {Key: "Errors", Value: "3"}},
+	}
+
+	assert.Equal(t, "error: postgres://app:"+redact.Mask+"@db:5432/app", r.RenderBox(b))
+	assert.Equal(t, []string{"error: postgres://app:" + redact.Mask + "@db:5432/app"}, r.RenderLines(b))
+
+	_, kvs := r.RenderLinesWithKVs(b)
+	assert.Equal(t, [][]box.KV{{{Key: "DB_PASSWORD", Value: redact.Mask}, {Key: "Errors", Value: "3"}}}, kvs)
+
+	assert.Equal(t, "hunter2", b.KVPairs[0].Value, "the caller's box is left alone")
+
+	grid := RenderGrid(r, []*box.Box{b}, GridLayout{})
+	assert.NotContains(t, grid, "s3cret", "grids render through the same renderer")
+}
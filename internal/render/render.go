@@ -1,6 +1,7 @@
 package render
 
 import (
+	"regexp"
 	"strings"
 
 	"boxed/internal/box"
@@ -12,13 +13,34 @@ const (
 	minLineWidth   = 30
 	maxLineWidth   = 100
 	contentPadding = 3
+
+	// borderSideWidth is the width of a vertical border glyph; every Lip Gloss preset
+	// we support uses single-cell sides.
+	borderSideWidth = 1
+
+	// MaxContentWidth is the widest a top-level box's content gets before values wrap.
+	MaxContentWidth = maxLineWidth
+
+	// FrameWidth is everything a box adds around its content width, used when
+	// fitting boxes inside a grid cell, a parent box, or the terminal.
+	FrameWidth = contentPadding*2 + borderSideWidth*2
+
+	// tabWidth expands tabs in body text; a raw tab would make lipgloss.Width
+	// disagree with what the terminal draws and break the right border.
+	tabWidth = 4
 )
 
 type Renderer interface {
 	RenderBox(b *box.Box) string
+	RenderLines(b *box.Box) []string
+	RenderLinesWithKVs(b *box.Box) ([]string, [][]box.KV)
 }
 
-type LipGlossRenderer struct{}
+// LipGlossRenderer draws boxes with Lip Gloss. Hyperlinks turns links in
+// user-written text into OSC 8 hyperlinks; otherwise they're shown as "text (url)".
+type LipGlossRenderer struct {
+	Hyperlinks bool
+}
 
 func NewLipGlossRenderer() *LipGlossRenderer {
 	return &LipGlossRenderer{}
@@ -29,6 +51,32 @@ func NewLipGlossRenderer() *LipGlossRenderer {
 // vertical position. This avoids re-rendering when the box size changes and separates
 // measurement concerns from styling concerns.
 func (r *LipGlossRenderer) RenderBox(b *box.Box) string {
+	return strings.Join(r.RenderLines(b), "\n")
+}
+
+// RenderLines returns the rendered box one terminal line at a time so callers can
+// compose several boxes (grids, nested boxes) without splitting the joined output.
+// Every line has the same visible width. Box.Height pads the box with blank content
+// rows before the footer, keeping the gradient spread over the full height.
+func (r *LipGlossRenderer) RenderLines(b *box.Box) []string {
+	lines, _ := r.renderLines(b, maxLineWidth)
+	return lines
+}
+
+// RenderLinesWithKVs also returns, for every line, the KV pairs shown on it: left
+// to right when pairs are in columns, including nested boxes, and none for borders,
+// the header, blank lines, the body and the footer. The viewer uses it to copy the
+// full value of a wrapped or column-laid-out pair, which the text alone can't tell.
+// Values are returned as shown, so "{red}3{/}" is copied as "3" unless the box is raw.
+func (r *LipGlossRenderer) RenderLinesWithKVs(b *box.Box) ([]string, [][]box.KV) {
+	return r.renderLines(b, maxLineWidth)
+}
+
+// renderLines takes the maximum line width as a parameter because nested boxes must
+// wrap their values in less space than their parent: each level loses FrameWidth.
+// Children are rendered twice, first at natural size to measure the parent, then
+// stretched to the parent's final content width so siblings line up.
+func (r *LipGlossRenderer) renderLines(b *box.Box, maxWidth int) ([]string, [][]box.KV) {
 	borderColor := r.getColorForType(b.Type)
 	border := r.getBorderStyle(b.BorderStyle)
 	gradient := r.getGradientForType(b.Type)
@@ -36,13 +84,48 @@ func (r *LipGlossRenderer) RenderBox(b *box.Box) string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(borderColor))
 	subtitleStyle := lipgloss.NewStyle().Italic(true).Faint(true)
 	keyStyle := lipgloss.NewStyle().Faint(true)
-
-	contentLines, maxContentWidth := processKVPairs(b.KVPairs, keyStyle)
-	headerText := buildHeaderText(b.Title, b.Subtitle, titleStyle, subtitleStyle)
+	in := inline{markup: b.Markup, hyperlinks: r.Hyperlinks}
+
+	// contentKVs runs parallel to contentLines.
+	contentLines, contentKVs, maxContentWidth := r.processKVPairs(b.KVPairs, b.Layout, in, keyStyle, gradient, maxWidth)
+	if b.Body != "" {
+		bodyLines, bodyWidth := processBody(b.Body, maxWidth, in)
+		if len(contentLines) > 0 {
+			contentLines = append(contentLines, "")
+		}
+		contentLines = append(contentLines, bodyLines...)
+		maxContentWidth = max(maxContentWidth, bodyWidth)
+	}
+	contentKVs = append(contentKVs, make([][]box.KV, len(contentLines)-len(contentKVs))...)
+	headerText := buildHeaderText(in.line(b.Title, titleStyle), in.line(b.Subtitle, subtitleStyle))
+
+	childMaxWidth := maxWidth - FrameWidth
+	for _, child := range b.Children {
+		childLines, _ := r.renderLines(child, childMaxWidth)
+		if len(childLines) > 0 && lipgloss.Width(childLines[0]) > maxContentWidth {
+			maxContentWidth = lipgloss.Width(childLines[0])
+		}
+	}
 
 	headerWidth := lipgloss.Width(headerText)
-	footerWidth := lipgloss.Width(b.Footer)
-	contentWidth := calculateBoxWidth(maxContentWidth, headerWidth, footerWidth, b.Width)
+	footer := ""
+	if b.Footer != "" {
+		footer = in.line(b.Footer, footerStyle)
+	}
+	footerWidth := lipgloss.Width(footer)
+	contentWidth := calculateBoxWidth(maxContentWidth, headerWidth, footerWidth, b.Width, maxWidth)
+
+	if len(b.Children) > 0 && len(contentLines) > 0 {
+		contentLines = append(contentLines, "")
+		contentKVs = append(contentKVs, nil)
+	}
+	for _, child := range b.Children {
+		sized := *child
+		sized.Width = contentWidth - FrameWidth
+		childLines, childKVs := r.renderLines(&sized, childMaxWidth)
+		contentLines = append(contentLines, childLines...)
+		contentKVs = append(contentKVs, childKVs...)
+	}
 
 	totalLines := 1
 	if headerText != "" {
@@ -58,7 +141,14 @@ func (r *LipGlossRenderer) RenderBox(b *box.Box) string {
 	}
 	totalLines++
 
+	padLines := 0
+	if b.Height > totalLines {
+		padLines = b.Height - totalLines
+		totalLines = b.Height
+	}
+
 	var lines []string
+	var lineKVs [][]box.KV
 	lineIndex := 0
 
 	borderColor = getGradientColorAt(gradient, float64(lineIndex)/float64(totalLines-1))
@@ -84,6 +174,8 @@ func (r *LipGlossRenderer) RenderBox(b *box.Box) string {
 		lines = append(lines, emptyLine)
 		lineIndex++
 
+		lineKVs = make([][]box.KV, len(lines), len(lines)+len(contentLines))
+		lineKVs = append(lineKVs, contentKVs...)
 		for _, line := range contentLines {
 			padding := contentWidth - lipgloss.Width(line)
 			leftPad := strings.Repeat(" ", contentPadding)
@@ -102,24 +194,130 @@ func (r *LipGlossRenderer) RenderBox(b *box.Box) string {
 		lineIndex++
 	}
 
+	for i := 0; i < padLines; i++ {
+		percentage := float64(lineIndex) / float64(totalLines-1)
+		sideColor := getGradientColorAt(gradient, percentage)
+		lines = append(lines, buildSideBorders(border, contentWidth, sideColor, sideColor, strings.Repeat(" ", contentWidth+contentPadding*2)))
+		lineIndex++
+	}
+
 	if b.Footer != "" {
 		percentage := float64(lineIndex) / float64(totalLines-1)
 		sideColor := getGradientColorAt(gradient, percentage)
-		lines = append(lines, buildFooterLine(border, b.Footer, contentWidth, sideColor))
+		lines = append(lines, buildFooterLine(border, footer, contentWidth, sideColor))
 		lineIndex++
 	}
 
 	borderColor = getGradientColorAt(gradient, float64(lineIndex)/float64(totalLines-1))
 	lines = append(lines, buildBorderLine(border, contentWidth, borderColor, border.BottomLeft, border.Bottom, border.BottomRight))
 
-	return strings.Join(lines, "\n")
+	lineKVs = append(lineKVs, make([][]box.KV, len(lines)-len(lineKVs))...)
+	return lines, lineKVs
 }
 
-func processKVPairs(kvPairs []box.KV, keyStyle lipgloss.Style) (lines []string, maxWidth int) {
+// kvColumnGap separates column groups; it is wider than the gap between keys and
+// values so a group's values don't read as the next group's keys.
+const kvColumnGap = contentPadding * 2
+
+// processKVPairs flows the pairs down into layout.Columns groups of equal height,
+// each aligned on its own keys, and lines the groups' pairs up row by row so a
+// wrapped value pushes the whole row down rather than shifting one group. lineKVs
+// holds the pairs with text on each line, left to right.
+func (r *LipGlossRenderer) processKVPairs(kvPairs []box.KV, layout box.KVLayout, in inline, keyStyle lipgloss.Style, gradient []string, lineWidth int) (lines []string, lineKVs [][]box.KV, maxWidth int) {
 	if len(kvPairs) == 0 {
-		return lines, maxWidth
+		return lines, lineKVs, maxWidth
+	}
+	if layout.MaxWidth > 0 {
+		lineWidth = min(lineWidth, layout.MaxWidth)
 	}
 
+	columns := r.kvColumnCount(kvPairs, layout, in, keyStyle, gradient, lineWidth)
+	rows := (len(kvPairs) + columns - 1) / columns
+	columns = (len(kvPairs) + rows - 1) / rows
+	columnWidth := (lineWidth - kvColumnGap*(columns-1)) / columns
+
+	var groups [][][]string
+	var groupWidths []int
+	for start := 0; start < len(kvPairs); start += rows {
+		blocks, width := r.kvBlocks(kvPairs[start:min(start+rows, len(kvPairs))], layout, in, keyStyle, gradient, columnWidth)
+		groups = append(groups, blocks)
+		groupWidths = append(groupWidths, width)
+	}
+
+	gap := strings.Repeat(" ", kvColumnGap)
+	for row := range rows {
+		height := 0
+		for _, blocks := range groups {
+			if row < len(blocks) {
+				height = max(height, len(blocks[row]))
+			}
+		}
+
+		for j := range height {
+			// Padding is only written once a later group has text on this line, so
+			// lines don't end in spaces that would count towards the box width.
+			var line strings.Builder
+			var kvs []box.KV
+			pending := ""
+			for g, blocks := range groups {
+				if g > 0 {
+					pending += gap
+				}
+				cell := ""
+				if row < len(blocks) && j < len(blocks[row]) {
+					cell = blocks[row][j]
+				}
+				if cell != "" {
+					line.WriteString(pending)
+					line.WriteString(cell)
+					pending = ""
+					kv := kvPairs[g*rows+row]
+					kv.Value = in.visible(kv.Value)
+					kvs = append(kvs, kv)
+				}
+				pending += strings.Repeat(" ", groupWidths[g]-lipgloss.Width(cell))
+			}
+			lines = append(lines, line.String())
+			lineKVs = append(lineKVs, kvs)
+			maxWidth = max(maxWidth, lipgloss.Width(line.String()))
+		}
+
+		if row < rows-1 && !layout.Compact {
+			lines = append(lines, "")
+			lineKVs = append(lineKVs, nil)
+		}
+	}
+	return lines, lineKVs, maxWidth
+}
+
+// kvColumnCount resolves layout.Columns to no more columns than fit copies of the
+// widest pair in lineWidth, so an explicit count is an upper bound rather than a
+// reason to squeeze values. Auto takes as many as fit, but never more columns than
+// rows: a handful of pairs stays a column instead of turning into a single line.
+func (r *LipGlossRenderer) kvColumnCount(kvPairs []box.KV, layout box.KVLayout, in inline, keyStyle lipgloss.Style, gradient []string, lineWidth int) int {
+	columns := layout.Columns
+	if columns != box.ColumnsAuto && columns <= 1 {
+		return 1
+	}
+
+	_, pairWidth := r.kvBlocks(kvPairs, layout, in, keyStyle, gradient, lineWidth)
+	fit := max(1, (lineWidth+kvColumnGap)/(pairWidth+kvColumnGap))
+	if columns != box.ColumnsAuto {
+		return max(1, min(columns, fit, len(kvPairs)))
+	}
+	columns = fit
+	for columns > 1 && columns > (len(kvPairs)+columns-1)/columns {
+		columns--
+	}
+	return max(1, min(columns, len(kvPairs)))
+}
+
+// kvBlocks renders each pair to its lines, keys aligned across all given pairs. It
+// is a method so per-row statuses can reuse the renderer's type colors. Rows with a
+// status get an icon prefix and a colored value; continuation lines of wrapped
+// values are indented past the icon so the text stays aligned.
+func (r *LipGlossRenderer) kvBlocks(kvPairs []box.KV, layout box.KVLayout, in inline, keyStyle lipgloss.Style, gradient []string, lineWidth int) (blocks [][]string, maxWidth int) {
+
 	var maxKeyWidth int
 	styledKeys := make([]string, len(kvPairs))
 	for i, kv := range kvPairs {
@@ -130,58 +328,144 @@ func processKVPairs(kvPairs []box.KV, keyStyle lipgloss.Style) (lines []string,
 		}
 	}
 
-	columnSeparator := contentPadding
+	// Numbers are right-aligned against the widest one; a value too long for its
+	// line is wrapped as usual instead.
+	values := make([]string, len(kvPairs))
 	for i, kv := range kvPairs {
-		key := styledKeys[i]
-		keyWidth := lipgloss.Width(key)
-		padding := strings.Repeat(" ", maxKeyWidth-keyWidth)
-		separator := strings.Repeat(" ", columnSeparator)
+		values[i] = in.visible(kv.Value)
+	}
+	var maxNumberWidth int
+	if layout.AlignNumbers {
+		for i, kv := range kvPairs {
+			if isAlignedNumber(kv, values[i]) {
+				maxNumberWidth = max(maxNumberWidth, lipgloss.Width(values[i]))
+			}
+		}
+	}
+
+	for i, kv := range kvPairs {
+		keyColumn := buildKeyColumn(styledKeys[i], maxKeyWidth, layout, keyStyle)
+		valueIndent := lipgloss.Width(keyColumn)
+		valueWidth := lineWidth - valueIndent
+
+		statusPrefix, continuationPrefix := "", ""
+		valueStyle := lipgloss.NewStyle()
+		if kv.Status != "" {
+			icon := getIconForType(kv.Status)
+			valueStyle = valueStyle.Foreground(lipgloss.Color(r.getColorForType(kv.Status)))
+			statusPrefix = valueStyle.Render(icon) + " "
+			continuationPrefix = strings.Repeat(" ", lipgloss.Width(icon)+1)
+			valueWidth -= lipgloss.Width(statusPrefix)
+		}
 
-		valueIndent := maxKeyWidth + columnSeparator
-		wrappedValue := wrapText(kv.Value, maxLineWidth-valueIndent)
-		valueLines := strings.Split(wrappedValue, "\n")
+		var valueLines []string
+		if len(kv.Series) > 0 {
+			valueLines = []string{buildSparkline(kv.Series, in.line(kv.Value, valueStyle), valueWidth, gradient)}
+		} else {
+			valueLines = in.lines(kv.Value, valueStyle, func(value string) string { return wrapText(value, valueWidth) })
+			if maxNumberWidth > 0 && isAlignedNumber(kv, values[i]) && maxNumberWidth <= valueWidth {
+				valueLines[0] = strings.Repeat(" ", maxNumberWidth-lipgloss.Width(values[i])) + valueLines[0]
+			}
+		}
 
+		block := make([]string, len(valueLines))
 		for j, valueLine := range valueLines {
-			var line string
 			if j == 0 {
-				line = key + padding + separator + valueLine
+				block[j] = keyColumn + statusPrefix + valueLine
 			} else {
 				indent := strings.Repeat(" ", valueIndent)
-				line = indent + valueLine
-			}
-			lines = append(lines, line)
-			lineWidth := lipgloss.Width(line)
-			if lineWidth > maxWidth {
-				maxWidth = lineWidth
+				block[j] = indent + continuationPrefix + valueLine
 			}
+			maxWidth = max(maxWidth, lipgloss.Width(block[j]))
 		}
+		blocks = append(blocks, block)
+	}
+	return blocks, maxWidth
+}
 
-		if i < len(kvPairs)-1 {
-			lines = append(lines, "")
+// minLeaderDots keeps a visible leader on the row with the widest key.
+const minLeaderDots = 3
+
+// buildKeyColumn renders everything left of a value: the key padded to the widest
+// key and the separator. Every row's column has the same width, which is where
+// values and their continuation lines start.
+func buildKeyColumn(styledKey string, maxKeyWidth int, layout box.KVLayout, keyStyle lipgloss.Style) string {
+	padding := strings.Repeat(" ", maxKeyWidth-lipgloss.Width(styledKey))
+
+	switch layout.Separator {
+	case "":
+		if layout.KeyAlign == box.KeyAlignRight {
+			return padding + styledKey + strings.Repeat(" ", contentPadding)
 		}
+		return styledKey + padding + strings.Repeat(" ", contentPadding)
+	case box.SeparatorDots:
+		if layout.KeyAlign == box.KeyAlignRight {
+			return padding + styledKey + " " + keyStyle.Render(strings.Repeat(".", minLeaderDots)) + " "
+		}
+		return styledKey + " " + keyStyle.Render(strings.Repeat(".", len(padding)+minLeaderDots)) + " "
+	case ":":
+		// A colon reads as part of the key, "Status:  ok", so it isn't spaced out.
+		colon := keyStyle.Render(":")
+		gap := strings.Repeat(" ", contentPadding-1)
+		if layout.KeyAlign == box.KeyAlignRight {
+			return padding + styledKey + colon + gap
+		}
+		return styledKey + colon + padding + gap
+	default:
+		separator := " " + keyStyle.Render(layout.Separator) + " "
+		if layout.KeyAlign == box.KeyAlignRight {
+			return padding + styledKey + separator
+		}
+		return styledKey + padding + separator
 	}
-	return lines, maxWidth
 }
 
-func buildHeaderText(title, subtitle string, titleStyle, subtitleStyle lipgloss.Style) string {
-	if title != "" {
-		headerText := titleStyle.Render(title)
-		if subtitle != "" {
-			headerText += " " + subtitleStyle.Render(subtitle)
+// leadingNumber matches values AlignNumbers treats as numeric: "42", "-1.5",
+// "2.4 GiB", "45%" and "3/3" all start with one.
+var leadingNumber = regexp.MustCompile(`^[-+]?(\d|\.\d)`)
+
+// isAlignedNumber reports whether AlignNumbers right-aligns the row's value, given
+// as it appears on screen. Sparklines have their own layout and multi-line values
+// would look ragged.
+func isAlignedNumber(kv box.KV, value string) bool {
+	return len(kv.Series) == 0 && !strings.Contains(value, "\n") && leadingNumber.MatchString(value)
+}
+
+// processBody keeps lines that fit exactly as written, preserving indentation and
+// alignment in things like log output, and only word-wraps lines that are too long.
+func processBody(body string, lineWidth int, in inline) (lines []string, maxWidth int) {
+	fit := func(line string) string {
+		if lipgloss.Width(line) > lineWidth {
+			return wrapText(line, lineWidth)
 		}
-		return headerText
-	} else if subtitle != "" {
-		return subtitleStyle.Render(subtitle)
+		return line
+	}
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.ReplaceAll(strings.TrimRight(line, "\r"), "\t", strings.Repeat(" ", tabWidth))
+		lines = append(lines, in.lines(line, lipgloss.NewStyle(), fit)...)
+	}
+
+	for _, line := range lines {
+		maxWidth = max(maxWidth, lipgloss.Width(line))
+	}
+	return lines, maxWidth
+}
+
+// buildHeaderText joins the title and subtitle, both already styled.
+func buildHeaderText(title, subtitle string) string {
+	if title != "" && subtitle != "" {
+		return title + " " + subtitle
 	}
-	return ""
+	return title + subtitle
 }
 
 // calculateBoxWidth enforces minimum and maximum width constraints while respecting
 // user-specified widths. The minimum prevents tiny boxes with short content, while the
 // maximum (applied to footers) prevents overly wide boxes from long timestamps or paths.
-func calculateBoxWidth(contentWidth, headerWidth, footerWidth, requestedWidth int) int {
-	if footerWidth > maxLineWidth {
-		footerWidth = maxLineWidth
+// The maximum is a parameter so nested boxes get less room than their parent.
+func calculateBoxWidth(contentWidth, headerWidth, footerWidth, requestedWidth, maxWidth int) int {
+	if footerWidth > maxWidth {
+		footerWidth = maxWidth
 	}
 
 	naturalWidth := contentWidth
@@ -1,10 +1,12 @@
 package render
 
 import (
+	"strings"
 	"testing"
 
 	"boxed/internal/box"
 
+	"github.com/charmbracelet/lipgloss/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +21,14 @@ func (m *mockRenderer) RenderBox(b *box.Box) string {
 	return output
 }
 
+func (m *mockRenderer) RenderLines(b *box.Box) []string {
+	return []string{m.RenderBox(b)}
+}
+
+func (m *mockRenderer) RenderLinesWithKVs(b *box.Box) ([]string, [][]box.KV) {
+	return m.RenderLines(b), [][]box.KV{b.KVPairs}
+}
+
 func TestMockRenderer(t *testing.T) {
 	mock := &mockRenderer{}
 	b := &box.Box{
@@ -101,6 +111,18 @@ func TestLipGlossRenderer_RenderBox(t *testing.T) {
 			},
 			contains: []string{"Deprecated"},
 		},
+		{
+			name: "per-row statuses",
+			box: &box.Box{
+				Type:  box.Warning,
+				Title: "Services",
+				KVPairs: []box.KV{
+					{Key: "api", Value: "healthy", Status: box.Success},
+					{Key: "db", Value: "down", Status: box.Error},
+				},
+			},
+			contains: []string{"✔", "healthy", "✖", "down"},
+		},
 		{
 			name: "box with different border styles",
 			box: &box.Box{
@@ -171,3 +193,245 @@ func TestLipGlossRenderer_MultiCellCharacters(t *testing.T) {
 		})
 	}
 }
+
+func TestLipGlossRenderer_NestedBoxes(t *testing.T) {
+	renderer := NewLipGlossRenderer()
+	longValue := strings.Repeat("word ", 40)
+	parent := &box.Box{
+		Type:    box.Warning,
+		Title:   "Release",
+		KVPairs: []box.KV{{Key: "env", Value: "prod"}},
+		Children: []*box.Box{
+			{Type: box.Success, Title: "api"},
+			{Type: box.Error, Title: "worker", KVPairs: []box.KV{{Key: "error", Value: longValue}}},
+		},
+	}
+
+	lines := renderer.RenderLines(parent)
+	output := strings.Join(lines, "\n")
+
+	for _, line := range lines {
+		assert.Equal(t, lipgloss.Width(lines[0]), lipgloss.Width(line), "all lines share the parent width")
+	}
+	assert.LessOrEqual(t, lipgloss.Width(lines[0]), maxLineWidth+FrameWidth, "children shrink to fit the parent")
+	assert.Contains(t, output, "api")
+	assert.Contains(t, output, "worker")
+
+	childLines := renderer.RenderLines(parent.Children[1])
+	assert.Greater(t, len(lines), len(childLines)+len(renderer.RenderLines(parent.Children[0])), "parent height includes children")
+}
+
+func TestProcessBody(t *testing.T) {
+	lines, width := processBody("short\n\tindented    text\n\n"+strings.Repeat("word ", 10), 20, inline{})
+
+	assert.Equal(t, []string{
+		"short",
+		"    indented    text",
+		"",
+		"word word word word",
+		"word word word word",
+		"word word",
+	}, lines)
+	assert.Equal(t, 20, width)
+}
+
+func TestProcessKVPairsLayout(t *testing.T) {
+	kvPairs := []box.KV{
+		{Key: "Nodes", Value: "3/3"},
+		{Key: "Size", Value: "2.4 GiB"},
+		{Key: "Region", Value: "eu-west-1"},
+	}
+
+	tests := []struct {
+		name   string
+		layout box.KVLayout
+		want   []string
+	}{
+		{
+			name:   "default",
+			layout: box.KVLayout{},
+			want:   []string{"Nodes    3/3", "", "Size     2.4 GiB", "", "Region   eu-west-1"},
+		},
+		{
+			name:   "compact",
+			layout: box.KVLayout{Compact: true},
+			want:   []string{"Nodes    3/3", "Size     2.4 GiB", "Region   eu-west-1"},
+		},
+		{
+			name:   "right-aligned keys",
+			layout: box.KVLayout{KeyAlign: box.KeyAlignRight, Compact: true},
+			want:   []string{" Nodes   3/3", "  Size   2.4 GiB", "Region   eu-west-1"},
+		},
+		{
+			name:   "colon",
+			layout: box.KVLayout{Separator: ":", Compact: true},
+			want:   []string{"Nodes:   3/3", "Size:    2.4 GiB", "Region:  eu-west-1"},
+		},
+		{
+			name:   "colon with right-aligned keys",
+			layout: box.KVLayout{Separator: ":", KeyAlign: box.KeyAlignRight, Compact: true},
+			want:   []string{" Nodes:  3/3", "  Size:  2.4 GiB", "Region:  eu-west-1"},
+		},
+		{
+			name:   "arrow",
+			layout: box.KVLayout{Separator: "→", Compact: true},
+			want:   []string{"Nodes  → 3/3", "Size   → 2.4 GiB", "Region → eu-west-1"},
+		},
+		{
+			name:   "dotted leaders",
+			layout: box.KVLayout{Separator: box.SeparatorDots, Compact: true},
+			want:   []string{"Nodes .... 3/3", "Size ..... 2.4 GiB", "Region ... eu-west-1"},
+		},
+		{
+			name:   "aligned numbers",
+			layout: box.KVLayout{AlignNumbers: true, Compact: true},
+			want:   []string{"Nodes        3/3", "Size     2.4 GiB", "Region   eu-west-1"},
+		},
+	}
+
+	renderer := NewLipGlossRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, _, width := renderer.processKVPairs(kvPairs, tt.layout, inline{}, lipgloss.NewStyle(), nil, maxLineWidth)
+
+			assert.Equal(t, tt.want, lines)
+			for _, line := range lines {
+				assert.LessOrEqual(t, lipgloss.Width(line), width)
+			}
+		})
+	}
+}
+
+func TestProcessKVPairsColumns(t *testing.T) {
+	kvPairs := []box.KV{
+		{Key: "A", Value: "1"},
+		{Key: "BB", Value: "2"},
+		{Key: "C", Value: "3"},
+		{Key: "DDDD", Value: "4"},
+		{Key: "E", Value: "5"},
+	}
+
+	tests := []struct {
+		name      string
+		layout    box.KVLayout
+		lineWidth int
+		want      []string
+	}{
+		{
+			name:      "two columns flow top to bottom",
+			layout:    box.KVLayout{Columns: 2, Compact: true},
+			lineWidth: maxLineWidth,
+			want: []string{
+				"A    1      DDDD   4",
+				"BB   2      E      5",
+				"C    3",
+			},
+		},
+		{
+			name:      "blank lines between rows",
+			layout:    box.KVLayout{Columns: 3},
+			lineWidth: maxLineWidth,
+			want: []string{
+				"A    1      C      3      E   5",
+				"",
+				"BB   2      DDDD   4",
+			},
+		},
+		{
+			name:      "auto keeps at least as many rows as columns",
+			layout:    box.KVLayout{Columns: box.ColumnsAuto, Compact: true},
+			lineWidth: maxLineWidth,
+			want: []string{
+				"A    1      DDDD   4",
+				"BB   2      E      5",
+				"C    3",
+			},
+		},
+		{
+			name:      "explicit columns are limited by the available width",
+			layout:    box.KVLayout{Columns: 10, Compact: true, MaxWidth: 30},
+			lineWidth: maxLineWidth,
+			want: []string{
+				"A    1      DDDD   4",
+				"BB   2      E      5",
+				"C    3",
+			},
+		},
+		{
+			name:      "auto is limited by the available width",
+			layout:    box.KVLayout{Columns: box.ColumnsAuto, Compact: true, MaxWidth: 20},
+			lineWidth: maxLineWidth,
+			want:      []string{"A      1", "BB     2", "C      3", "DDDD   4", "E      5"},
+		},
+	}
+
+	renderer := NewLipGlossRenderer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, _, width := renderer.processKVPairs(kvPairs, tt.layout, inline{}, lipgloss.NewStyle(), nil, tt.lineWidth)
+
+			assert.Equal(t, tt.want, lines)
+			assert.Equal(t, lipgloss.Width(lines[0]), width)
+			for _, line := range lines {
+				assert.Equal(t, strings.TrimRight(line, " "), line, "no trailing spaces")
+			}
+		})
+	}
+}
+
+func TestRenderLinesWithKVs(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+	b := &box.Box{
+		Type:     box.Info,
+		Title:    "Deploy",
+		KVPairs:  []box.KV{{Key: "Alpha", Value: "one"}, {Key: "Beta", Value: "two"}, {Key: "Gamma", Value: "three"}},
+		Layout:   box.KVLayout{Columns: 2, Separator: ":", Compact: true},
+		Body:     "body text",
+		Children: []*box.Box{{Type: box.Success, KVPairs: []box.KV{{Key: "Notes", Value: long}}}},
+		Footer:   "done",
+		Width:    50,
+	}
+
+	lines, lineKVs := NewLipGlossRenderer().RenderLinesWithKVs(b)
+	require.Len(t, lineKVs, len(lines))
+
+	keysOn := func(i int) []string {
+		var keys []string
+		for _, kv := range lineKVs[i] {
+			keys = append(keys, kv.Key)
+		}
+		return keys
+	}
+	var owned [][]string
+	for i := range lines {
+		if keys := keysOn(i); keys != nil {
+			owned = append(owned, keys)
+		}
+	}
+
+	// Alpha and Gamma share a line in their columns; the child's Notes wraps, and
+	// each of its lines copies the whole value.
+	assert.Equal(t, [][]string{{"Alpha", "Gamma"}, {"Beta"}, {"Notes"}, {"Notes"}}, owned)
+	assert.Nil(t, lineKVs[0], "top border")
+	assert.Nil(t, lineKVs[1], "header")
+	assert.Nil(t, lineKVs[len(lines)-2], "footer")
+}
+
+func TestRenderLinesWithKVs_ShownValues(t *testing.T) {
+	kvs := []box.KV{{Key: "Failed", Value: "{red}3{/}"}, {Key: "Build", Value: "[log](https://ci.example.com/1)"}}
+
+	shown := func(b *box.Box) []string {
+		_, lineKVs := NewLipGlossRenderer().RenderLinesWithKVs(b)
+		var values []string
+		for _, line := range lineKVs {
+			for _, kv := range line {
+				values = append(values, kv.Value)
+			}
+		}
+		return values
+	}
+
+	assert.Equal(t, []string{"3", "log (https://ci.example.com/1)"}, shown(&box.Box{Type: box.Info, KVPairs: kvs, Markup: true}))
+	assert.Equal(t, []string{"{red}3{/}", "[log](https://ci.example.com/1)"}, shown(&box.Box{Type: box.Info, KVPairs: kvs}), "raw boxes keep the text as written")
+	assert.Equal(t, "{red}3{/}", kvs[0].Value, "the box is left alone")
+}
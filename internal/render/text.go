@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // wrapText implements word-boundary wrapping with special handling for long unbreakable
@@ -104,35 +105,12 @@ func breakLongWord(word string, maxWidth int) string {
 }
 
 // truncateText handles header/footer text that can't wrap to multiple lines because they're
-// embedded in border components. Uses rune iteration rather than byte slicing to correctly
-// handle multi-byte Unicode characters (emoji, CJK text).
+// embedded in border components. The text may already be styled, so truncation is left to
+// ansi.Truncate, which measures multi-cell characters (emoji, CJK text) correctly and keeps
+// escape sequences intact, including the ones that close styles and hyperlinks.
 func truncateText(text string, maxWidth int) string {
 	if maxWidth <= 3 {
 		return "..."
 	}
-
-	textWidth := lipgloss.Width(text)
-	if textWidth <= maxWidth {
-		return text
-	}
-
-	targetWidth := maxWidth - 3
-	runes := []rune(text)
-	var currentWidth int
-	var cutoff int
-
-	for i, r := range runes {
-		runeWidth := lipgloss.Width(string(r))
-		if currentWidth+runeWidth > targetWidth {
-			cutoff = i
-			break
-		}
-		currentWidth += runeWidth
-	}
-
-	if cutoff == 0 {
-		return "..."
-	}
-
-	return string(runes[:cutoff]) + "..."
+	return ansi.Truncate(text, maxWidth, "...")
 }
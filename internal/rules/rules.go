@@ -0,0 +1,224 @@
+package rules
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"boxed/internal/box"
+	"boxed/internal/validate"
+)
+
+// Rule maps a condition on a single KV value to a status, e.g. "CPU>90:error".
+// Rules replace the threshold checks that example scripts used to reimplement in
+// bash: they are evaluated after parsing so they see exactly what gets rendered.
+type Rule struct {
+	Key     string
+	Op      string
+	Operand string
+	Status  box.BoxType
+
+	number  float64
+	numeric bool
+	pattern *regexp.Regexp
+}
+
+// operators is ordered so two-character operators are matched before their
+// one-character prefixes.
+var operators = []string{">=", "<=", "==", "!=", ">", "<", "=", "~"}
+
+var leadingNumber = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)`)
+
+// Parse reads a rule in the form "<key><op><operand>:<status>". The status is
+// taken from the last colon so operands may contain colons (e.g. times). Keys
+// may contain spaces; they are matched case-insensitively against KV keys.
+func Parse(s string) (Rule, error) {
+	sep := strings.LastIndex(s, ":")
+	if sep < 0 {
+		return Rule{}, fmt.Errorf("invalid rule %q: must be in format key<op>value:status", s)
+	}
+
+	status := strings.TrimSpace(s[sep+1:])
+	if err := validate.BoxType(status); err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %w", s, err)
+	}
+
+	condition := s[:sep]
+	opIndex := strings.IndexAny(condition, "<>=!~")
+	if opIndex <= 0 {
+		return Rule{}, fmt.Errorf("invalid rule %q: missing key or operator (one of %s)", s, strings.Join(operators, " "))
+	}
+
+	var op string
+	for _, candidate := range operators {
+		if strings.HasPrefix(condition[opIndex:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return Rule{}, fmt.Errorf("invalid rule %q: unknown operator", s)
+	}
+
+	rule := Rule{
+		Key:     strings.TrimSpace(condition[:opIndex]),
+		Op:      op,
+		Operand: strings.TrimSpace(condition[opIndex+len(op):]),
+		Status:  box.BoxType(status),
+	}
+	if rule.Op == "=" {
+		rule.Op = "=="
+	}
+
+	if rule.Key == "" {
+		return Rule{}, fmt.Errorf("invalid rule %q: key cannot be empty", s)
+	}
+
+	switch rule.Op {
+	case "~":
+		pattern, err := regexp.Compile(rule.Operand)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid rule %q: %w", s, err)
+		}
+		rule.pattern = pattern
+	default:
+		if n, err := strconv.ParseFloat(rule.Operand, 64); err == nil {
+			rule.number = n
+			rule.numeric = true
+		} else if rule.Op != "==" && rule.Op != "!=" {
+			return Rule{}, fmt.Errorf("invalid rule %q: operator %s requires a number", s, rule.Op)
+		}
+	}
+
+	return rule, nil
+}
+
+// ParseAll parses every rule, failing on the first invalid one.
+func ParseAll(specs []string) ([]Rule, error) {
+	parsed := make([]Rule, 0, len(specs))
+	for _, spec := range specs {
+		rule, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+// Read returns the rule specs from a rules file: one rule per line, with blank
+// lines and lines starting with '#' ignored. Specs are returned unparsed so they
+// can be combined with --rule flags and validated together.
+func Read(r io.Reader) ([]string, error) {
+	var specs []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		specs = append(specs, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return specs, nil
+}
+
+// Matches reports whether the rule applies to the given KV pair. Numeric
+// operands compare against the leading number of the value, so "45%" and
+// "120ms" work without the caller stripping units.
+func (r Rule) Matches(kv box.KV) bool {
+	if !strings.EqualFold(strings.TrimSpace(kv.Key), r.Key) {
+		return false
+	}
+
+	value := strings.TrimSpace(kv.Value)
+
+	if r.Op == "~" {
+		return r.pattern.MatchString(value)
+	}
+
+	if r.numeric {
+		match := leadingNumber.FindString(value)
+		if match == "" {
+			return false
+		}
+		n, err := strconv.ParseFloat(match, 64)
+		if err != nil {
+			return false
+		}
+		switch r.Op {
+		case ">":
+			return n > r.number
+		case ">=":
+			return n >= r.number
+		case "<":
+			return n < r.number
+		case "<=":
+			return n <= r.number
+		case "==":
+			return n == r.number
+		case "!=":
+			return n != r.number
+		}
+		return false
+	}
+
+	switch r.Op {
+	case "==":
+		return value == r.Operand
+	case "!=":
+		return value != r.Operand
+	}
+	return false
+}
+
+// Apply evaluates rules against the KV pairs in place, giving each row the most
+// severe status of any matching rule (explicit row statuses are kept if they are
+// worse). It returns the most severe status across base and all rows, so rules can
+// only escalate a box, never hide a problem the caller already reported.
+func Apply(kvPairs []box.KV, rules []Rule, base box.BoxType) box.BoxType {
+	result := base
+	for i := range kvPairs {
+		for _, rule := range rules {
+			if rule.Matches(kvPairs[i]) {
+				kvPairs[i].Status = Worst(kvPairs[i].Status, rule.Status)
+			}
+		}
+		result = Worst(result, kvPairs[i].Status)
+	}
+	return result
+}
+
+// Worst returns the more severe of two statuses. The empty status ranks below
+// every real type so it never wins over an actual result.
+func Worst(a, b box.BoxType) box.BoxType {
+	if severity(b) > severity(a) {
+		return b
+	}
+	return a
+}
+
+func severity(t box.BoxType) int {
+	switch t {
+	case box.Success:
+		return 1
+	case box.Info:
+		return 2
+	case box.Pending:
+		return 3
+	case box.Warning:
+		return 4
+	case box.Error:
+		return 5
+	default:
+		return 0
+	}
+}
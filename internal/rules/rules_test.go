@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		key     string
+		op      string
+		operand string
+		status  box.BoxType
+		wantErr bool
+	}{
+		{name: "greater than", spec: "CPU>90:error", key: "CPU", op: ">", operand: "90", status: box.Error},
+		{name: "greater or equal", spec: "Disk >= 80 : warning", key: "Disk", op: ">=", operand: "80", status: box.Warning},
+		{name: "single equals is equality", spec: "State=down:error", key: "State", op: "==", operand: "down", status: box.Error},
+		{name: "not equal string", spec: "State!=running:warning", key: "State", op: "!=", operand: "running", status: box.Warning},
+		{name: "regex", spec: "Log~(?i)fatal:error", key: "Log", op: "~", operand: "(?i)fatal", status: box.Error},
+		{name: "key with spaces", spec: "Failing Pods>0:error", key: "Failing Pods", op: ">", operand: "0", status: box.Error},
+		{name: "operand with colon", spec: "Uptime==00:00:error", key: "Uptime", op: "==", operand: "00:00", status: box.Error},
+		{name: "missing status", spec: "CPU>90", wantErr: true},
+		{name: "invalid status", spec: "CPU>90:fatal", wantErr: true},
+		{name: "missing operator", spec: "CPU:error", wantErr: true},
+		{name: "missing key", spec: ">90:error", wantErr: true},
+		{name: "non-numeric comparison", spec: "CPU>high:error", wantErr: true},
+		{name: "invalid regex", spec: "Log~(:error", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.spec)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid rule")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.key, rule.Key)
+			assert.Equal(t, tt.op, rule.Op)
+			assert.Equal(t, tt.operand, rule.Operand)
+			assert.Equal(t, tt.status, rule.Status)
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		kv   box.KV
+		want bool
+	}{
+		{"number with unit above threshold", "CPU>90:error", box.KV{Key: "CPU", Value: "95%"}, true},
+		{"number with unit below threshold", "CPU>90:error", box.KV{Key: "CPU", Value: "45%"}, false},
+		{"case-insensitive key", "cpu>90:error", box.KV{Key: "CPU", Value: "95"}, true},
+		{"different key", "CPU>90:error", box.KV{Key: "Memory", Value: "95"}, false},
+		{"non-numeric value", "CPU>90:error", box.KV{Key: "CPU", Value: "n/a"}, false},
+		{"decimal", "Load<=0.5:info", box.KV{Key: "Load", Value: "0.25"}, true},
+		{"numeric equality", "Exit==0:success", box.KV{Key: "Exit", Value: "0"}, true},
+		{"string equality", "State==down:error", box.KV{Key: "State", Value: "down"}, true},
+		{"string inequality", "State!=running:warning", box.KV{Key: "State", Value: "running"}, false},
+		{"regex match", "Log~fatal|panic:error", box.KV{Key: "Log", Value: "panic: nil map"}, true},
+		{"sparkline latest value", "Latency>100:warning", box.KV{Key: "Latency", Value: "120", Series: []float64{10, 120}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.spec)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, rule.Matches(tt.kv))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	parsed, err := ParseAll([]string{"CPU>90:error", "CPU>70:warning", "Disk>80:warning"})
+	require.NoError(t, err)
+
+	kvPairs := []box.KV{
+		{Key: "CPU", Value: "95%"},
+		{Key: "Disk", Value: "85%"},
+		{Key: "Memory", Value: "40%"},
+		{Key: "Cache", Value: "ok", Status: box.Info},
+	}
+
+	got := Apply(kvPairs, parsed, box.Success)
+
+	assert.Equal(t, box.Error, got)
+	assert.Equal(t, box.Error, kvPairs[0].Status, "most severe matching rule wins")
+	assert.Equal(t, box.Warning, kvPairs[1].Status)
+	assert.Equal(t, box.BoxType(""), kvPairs[2].Status)
+	assert.Equal(t, box.Info, kvPairs[3].Status, "explicit status is kept")
+}
+
+func TestApply_NeverDowngrades(t *testing.T) {
+	parsed, err := ParseAll([]string{"CPU>90:error"})
+	require.NoError(t, err)
+
+	got := Apply([]box.KV{{Key: "CPU", Value: "10%"}}, parsed, box.Warning)
+
+	assert.Equal(t, box.Warning, got)
+}
+
+func TestWorst(t *testing.T) {
+	assert.Equal(t, box.Error, Worst(box.Warning, box.Error))
+	assert.Equal(t, box.Warning, Worst(box.Warning, box.Info))
+	assert.Equal(t, box.Info, Worst(box.Success, box.Info))
+	assert.Equal(t, box.Pending, Worst(box.Pending, box.Info))
+	assert.Equal(t, box.Warning, Worst(box.Pending, box.Warning))
+	assert.Equal(t, box.Success, Worst("", box.Success))
+	assert.Equal(t, box.Success, Worst(box.Success, ""))
+}
+
+func TestRead(t *testing.T) {
+	input := "# thresholds\nCPU>90:error\n\n  Disk>80:warning  \n"
+
+	got, err := Read(strings.NewReader(input))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CPU>90:error", "Disk>80:warning"}, got)
+}
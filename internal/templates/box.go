@@ -0,0 +1,38 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+
+	"boxed/internal/box"
+)
+
+// ListBox shows the available templates with their variables. The footer names the
+// directories searched, which is what users need when a template they expect is
+// missing.
+func ListBox(found []*Template, dirs []string) *box.Box {
+	b := &box.Box{
+		Type:   box.Info,
+		Title:  "Templates",
+		Footer: strings.Join(dirs, " • "),
+	}
+	if len(found) == 0 {
+		b.Subtitle = "none found"
+		b.Body = "Add a .yaml file to one of the directories below."
+		return b
+	}
+
+	b.Subtitle = fmt.Sprintf("%d available", len(found))
+	for _, t := range found {
+		value := strings.TrimPrefix(t.Usage(), t.Name)
+		value = strings.TrimSpace(value)
+		if value == "" {
+			value = "no variables"
+		}
+		if t.Description != "" {
+			value = t.Description + " — " + value
+		}
+		b.KVPairs = append(b.KVPairs, box.KV{Key: t.Name, Value: value})
+	}
+	return b
+}
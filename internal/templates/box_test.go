@@ -0,0 +1,39 @@
+package templates
+
+import (
+	"testing"
+
+	"boxed/internal/box"
+	"boxed/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListBox(t *testing.T) {
+	dirs := []string{".boxed/templates", "/home/me/.config/boxed/templates"}
+
+	t.Run("templates with variables", func(t *testing.T) {
+		found := []*Template{
+			{Name: "backup", Settings: config.Settings{Title: "Backup"}},
+			{Name: "deploy", Description: "Deployment summary", Settings: config.Settings{Title: "Deploy {{version}}", Footer: "by {{user}}"}, Defaults: map[string]string{"user": "ci"}},
+		}
+
+		b := ListBox(found, dirs)
+
+		assert.Equal(t, box.Info, b.Type)
+		assert.Equal(t, "2 available", b.Subtitle)
+		assert.Equal(t, []box.KV{
+			{Key: "backup", Value: "no variables"},
+			{Key: "deploy", Value: "Deployment summary — version=… [user=ci]"},
+		}, b.KVPairs)
+		assert.Equal(t, ".boxed/templates • /home/me/.config/boxed/templates", b.Footer)
+	})
+
+	t.Run("no templates", func(t *testing.T) {
+		b := ListBox(nil, dirs)
+
+		assert.Equal(t, "none found", b.Subtitle)
+		assert.Empty(t, b.KVPairs)
+		assert.NotEmpty(t, b.Body)
+	})
+}
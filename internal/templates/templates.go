@@ -0,0 +1,269 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"boxed/internal/box"
+	"boxed/internal/config"
+	"boxed/internal/parser"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultType is used when a template doesn't set one.
+const DefaultType = "info"
+
+// placeholder matches "{{name}}"; double braces keep placeholders apart from
+// anything a literal title or value would plausibly contain.
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+// Template is a saved box layout: the same settings as a config file preset, plus a
+// box type and variable defaults. The title, subtitle, body, footer, KV rows, rules
+// and type may contain {{name}} placeholders; a variable without an entry in
+// Defaults is required. KV rows are key=value, one pair per row, and are dropped when
+// their value comes out empty, so a variable that defaults to "" makes its row
+// optional.
+type Template struct {
+	Name string `yaml:"-"`
+	Path string `yaml:"-"`
+
+	config.Settings `yaml:",inline"`
+	Description     string            `yaml:"description"`
+	Type            string            `yaml:"type"`
+	Defaults        map[string]string `yaml:"defaults"`
+}
+
+// reservedNames are subcommands of `boxed tmpl`, which a template of the same name
+// could never be invoked past.
+var reservedNames = []string{"list"}
+
+// Variable is a placeholder used by a template.
+type Variable struct {
+	Name     string
+	Default  string
+	Required bool
+}
+
+// Dirs returns the template directories in lookup order: $BOXED_TEMPLATES (a
+// path list), the nearest .boxed/templates in dir or its parents, and templates in
+// the user config directory. Earlier directories win when names collide, so a
+// project can override a user template.
+func Dirs(dir string, getenv func(string) string) []string {
+	var dirs []string
+	if paths := getenv("BOXED_TEMPLATES"); paths != "" {
+		dirs = append(dirs, filepath.SplitList(paths)...)
+	}
+	for current := dir; ; {
+		candidate := filepath.Join(current, ".boxed", "templates")
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			dirs = append(dirs, candidate)
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+	if userDir := config.UserDir(getenv); userDir != "" {
+		dirs = append(dirs, filepath.Join(userDir, "templates"))
+	}
+	return dirs
+}
+
+// Load reads a template file; its name is the file name without extension.
+// Unknown keys are rejected like in config files.
+func Load(path string) (*Template, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open template: %w", err)
+	}
+	defer file.Close()
+
+	t := &Template{}
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(t); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid template %s: %w", path, err)
+	}
+	t.Path = path
+	t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if slices.Contains(reservedNames, t.Name) {
+		return nil, fmt.Errorf("invalid template %s: %q is a tmpl subcommand, rename the file", path, t.Name)
+	}
+	return t, nil
+}
+
+// Discover loads every .yaml and .yml file in dirs, sorted by name. Missing
+// directories are skipped since none of them has to exist.
+func Discover(dirs []string) ([]*Template, error) {
+	var found []*Template
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read templates directory: %w", err)
+		}
+
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			t, err := Load(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			if seen[t.Name] {
+				continue
+			}
+			seen[t.Name] = true
+			found = append(found, t)
+		}
+	}
+	slices.SortFunc(found, func(a, b *Template) int { return strings.Compare(a.Name, b.Name) })
+	return found, nil
+}
+
+// Find returns the template called name from dirs.
+func Find(dirs []string, name string) (*Template, error) {
+	found, err := Discover(dirs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(found))
+	for _, t := range found {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("unknown template %q: no templates found in %s", name, strings.Join(dirs, ", "))
+	}
+	return nil, fmt.Errorf("unknown template %q, must be one of: %s", name, strings.Join(names, ", "))
+}
+
+// fields lists the template's text in display order, which is also the order
+// variables are reported in.
+func (t *Template) fields() []string {
+	fields := []string{t.Type, t.Title, t.Subtitle}
+	fields = append(fields, t.KV...)
+	fields = append(fields, t.Body, t.Footer)
+	return append(fields, t.Rules...)
+}
+
+// Variables returns the placeholders the template uses, in order of first use.
+func (t *Template) Variables() []Variable {
+	var vars []Variable
+	seen := map[string]bool{}
+	for _, field := range t.fields() {
+		for _, match := range placeholder.FindAllStringSubmatch(field, -1) {
+			name := match[1]
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			value, ok := t.Defaults[name]
+			vars = append(vars, Variable{Name: name, Default: value, Required: !ok})
+		}
+	}
+	return vars
+}
+
+// Usage shows how to invoke the template, e.g. "deploy version=… env=… [region=us-east-1]".
+func (t *Template) Usage() string {
+	parts := []string{t.Name}
+	for _, v := range t.Variables() {
+		if v.Required {
+			parts = append(parts, v.Name+"=…")
+		} else {
+			parts = append(parts, fmt.Sprintf("[%s=%s]", v.Name, v.Default))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Render fills in the placeholders and returns the box type and options for
+// parser.ParseBox. Values for variables the template doesn't use are rejected so
+// a misspelled name doesn't go unnoticed; all missing required variables are
+// reported at once.
+func (t *Template) Render(values map[string]string) (string, parser.Options, error) {
+	vars := t.Variables()
+	known := map[string]bool{}
+	for _, v := range vars {
+		known[v.Name] = true
+	}
+
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return "", parser.Options{}, fmt.Errorf("template %q has no variable %s (usage: boxed tmpl %s)", t.Name, strings.Join(unknown, ", "), t.Usage())
+	}
+
+	resolved := map[string]string{}
+	var missing []string
+	for _, v := range vars {
+		value, ok := values[v.Name]
+		switch {
+		case ok:
+			resolved[v.Name] = value
+		case v.Required:
+			missing = append(missing, v.Name)
+		default:
+			resolved[v.Name] = v.Default
+		}
+	}
+	if len(missing) > 0 {
+		return "", parser.Options{}, fmt.Errorf("template %q is missing required variables: %s (usage: boxed tmpl %s)", t.Name, strings.Join(missing, ", "), t.Usage())
+	}
+
+	expand := func(s string) string {
+		return placeholder.ReplaceAllStringFunc(s, func(match string) string {
+			return resolved[placeholder.FindStringSubmatch(match)[1]]
+		})
+	}
+
+	settings := t.Settings
+	settings.Title = expand(t.Title)
+	settings.Subtitle = expand(t.Subtitle)
+	settings.Body = expand(t.Body)
+	settings.Footer = expand(t.Footer)
+	settings.KV, settings.Rules = nil, nil
+	opts := settings.Options()
+	for _, row := range t.KV {
+		// Split before expanding and keep the pair whole, so a value can neither
+		// change which part is the key nor add rows of its own.
+		key, value, ok := strings.Cut(row, "=")
+		if !ok {
+			return "", parser.Options{}, fmt.Errorf("template %q: invalid kv row %q, must be key=value", t.Name, row)
+		}
+		if value = expand(value); value != "" {
+			opts.KVPairs = append(opts.KVPairs, box.KV{Key: expand(key), Value: value})
+		}
+	}
+	for _, rule := range t.Rules {
+		opts.Rules = append(opts.Rules, expand(rule))
+	}
+
+	boxType := expand(t.Type)
+	if boxType == "" {
+		boxType = DefaultType
+	}
+	return boxType, opts, nil
+}
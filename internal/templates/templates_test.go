@@ -0,0 +1,225 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"boxed/internal/box"
+	"boxed/internal/config"
+	"boxed/internal/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deployTemplate = `
+description: Deployment summary
+type: success
+title: Deploy {{version}}
+subtitle: "{{ env }}"
+kv:
+  - Version={{version}}
+  - Environment={{env}}
+  - Region={{region}}
+  - Notes={{notes}}
+footer: Deployed by {{user}}
+rules:
+  - Errors>{{max_errors}}:error
+defaults:
+  region: us-east-1
+  notes: ""
+  user: ci
+  max_errors: "0"
+`
+
+func writeTemplate(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func loadDeploy(t *testing.T) *Template {
+	t.Helper()
+	tmpl, err := Load(writeTemplate(t, t.TempDir(), "deploy.yaml", deployTemplate))
+	require.NoError(t, err)
+	return tmpl
+}
+
+func TestVariables(t *testing.T) {
+	tmpl := loadDeploy(t)
+
+	assert.Equal(t, "deploy", tmpl.Name)
+	assert.Equal(t, []Variable{
+		{Name: "version", Required: true},
+		{Name: "env", Required: true},
+		{Name: "region", Default: "us-east-1"},
+		{Name: "notes"},
+		{Name: "user", Default: "ci"},
+		{Name: "max_errors", Default: "0"},
+	}, tmpl.Variables())
+	assert.Equal(t, "deploy version=… env=… [region=us-east-1] [notes=] [user=ci] [max_errors=0]", tmpl.Usage())
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		wantType string
+		wantOpts parser.Options
+		wantErr  string
+	}{
+		{
+			name:     "defaults fill optional variables and empty rows are dropped",
+			values:   map[string]string{"version": "1.2", "env": "prod"},
+			wantType: "success",
+			wantOpts: parser.Options{
+				Title:    "Deploy 1.2",
+				Subtitle: "prod",
+				KVPairs:  []box.KV{{Key: "Version", Value: "1.2"}, {Key: "Environment", Value: "prod"}, {Key: "Region", Value: "us-east-1"}},
+				Footer:   "Deployed by ci",
+				Rules:    []string{"Errors>0:error"},
+			},
+		},
+		{
+			name:     "values override defaults",
+			values:   map[string]string{"version": "1.3", "env": "staging", "notes": "hotfix", "region": "eu-west-1"},
+			wantType: "success",
+			wantOpts: parser.Options{
+				Title:    "Deploy 1.3",
+				Subtitle: "staging",
+				KVPairs:  []box.KV{{Key: "Version", Value: "1.3"}, {Key: "Environment", Value: "staging"}, {Key: "Region", Value: "eu-west-1"}, {Key: "Notes", Value: "hotfix"}},
+				Footer:   "Deployed by ci",
+				Rules:    []string{"Errors>0:error"},
+			},
+		},
+		{
+			name:     "values stay in their row",
+			values:   map[string]string{"version": "1.4", "env": "prod", "notes": "see https://example.com/?a=1,Region=x"},
+			wantType: "success",
+			wantOpts: parser.Options{
+				Title:    "Deploy 1.4",
+				Subtitle: "prod",
+				KVPairs:  []box.KV{{Key: "Version", Value: "1.4"}, {Key: "Environment", Value: "prod"}, {Key: "Region", Value: "us-east-1"}, {Key: "Notes", Value: "see https://example.com/?a=1,Region=x"}},
+				Footer:   "Deployed by ci",
+				Rules:    []string{"Errors>0:error"},
+			},
+		},
+		{
+			name:    "all missing variables are listed",
+			values:  map[string]string{},
+			wantErr: `template "deploy" is missing required variables: version, env (usage: boxed tmpl deploy version=… env=…`,
+		},
+		{
+			name:    "unknown variables are rejected",
+			values:  map[string]string{"version": "1.2", "env": "prod", "enviroment": "prod"},
+			wantErr: `template "deploy" has no variable enviroment`,
+		},
+	}
+
+	tmpl := loadDeploy(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			boxType, opts, err := tmpl.Render(tt.values)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, boxType)
+			assert.Equal(t, tt.wantOpts, opts)
+		})
+	}
+}
+
+func TestRenderTypeFromVariable(t *testing.T) {
+	tmpl := &Template{Name: "status", Type: "{{status}}", Settings: config.Settings{Title: "Status"}, Defaults: map[string]string{"status": "success"}}
+
+	boxType, _, err := tmpl.Render(map[string]string{"status": "warning"})
+	require.NoError(t, err)
+	assert.Equal(t, "warning", boxType)
+
+	boxType, _, err = (&Template{Name: "plain", Settings: config.Settings{Title: "Plain"}}).Render(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultType, boxType)
+}
+
+func TestRenderInvalidRow(t *testing.T) {
+	_, _, err := (&Template{Name: "bad", Settings: config.Settings{KV: []string{"no separator"}}}).Render(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid kv row "no separator"`)
+}
+
+func TestRenderLayout(t *testing.T) {
+	tmpl, err := Load(writeTemplate(t, t.TempDir(), "table.yaml", "title: Table\nwidth: 60\ncolumns: auto\nraw: true\nkey-align: right\n"))
+	require.NoError(t, err)
+
+	_, opts, err := tmpl.Render(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 60, opts.Width)
+	assert.Equal(t, "auto", opts.Columns)
+	require.NotNil(t, opts.Raw)
+	assert.True(t, *opts.Raw)
+	assert.Equal(t, "right", opts.Layout.KeyAlign)
+}
+
+func TestLoadRejectsSubcommandNames(t *testing.T) {
+	_, err := Load(writeTemplate(t, t.TempDir(), "list.yaml", "title: List\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"list" is a tmpl subcommand`)
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	_, err := Load(writeTemplate(t, t.TempDir(), "typo.yaml", "titel: Deploy\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "titel")
+}
+
+func TestDiscoverAndFind(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	user := filepath.Join(root, "user")
+	writeTemplate(t, project, "deploy.yaml", "description: project deploy\n")
+	writeTemplate(t, user, "deploy.yml", "description: user deploy\n")
+	writeTemplate(t, user, "backup.yml", "description: backup\n")
+	writeTemplate(t, user, "README.md", "not a template")
+
+	dirs := []string{project, filepath.Join(root, "missing"), user}
+	found, err := Discover(dirs)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "backup", found[0].Name)
+	assert.Equal(t, "deploy", found[1].Name)
+	assert.Equal(t, "project deploy", found[1].Description, "earlier directories win")
+
+	tmpl, err := Find(dirs, "backup")
+	require.NoError(t, err)
+	assert.Equal(t, "backup", tmpl.Description)
+
+	_, err = Find(dirs, "release")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown template "release", must be one of: backup, deploy`)
+
+	_, err = Find([]string{filepath.Join(root, "missing")}, "release")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no templates found in")
+}
+
+func TestDirs(t *testing.T) {
+	root := t.TempDir()
+	projectTemplates := filepath.Join(root, "repo", ".boxed", "templates")
+	nested := filepath.Join(root, "repo", "scripts")
+	require.NoError(t, os.MkdirAll(projectTemplates, 0o755))
+	require.NoError(t, os.MkdirAll(nested, 0o755))
+
+	env := map[string]string{
+		"BOXED_TEMPLATES": "/opt/a" + string(filepath.ListSeparator) + "/opt/b",
+		"XDG_CONFIG_HOME": "/xdg",
+	}
+	dirs := Dirs(nested, func(key string) string { return env[key] })
+
+	assert.Equal(t, []string{"/opt/a", "/opt/b", projectTemplates, "/xdg/boxed/templates"}, dirs)
+}
@@ -13,7 +13,7 @@ import (
 func BoxType(t string) error {
 	boxType := box.BoxType(t)
 	if !boxType.IsValid() {
-		validTypes := make([]string, 0, 4)
+		validTypes := make([]string, 0, len(box.AllBoxTypes()))
 		for _, bt := range box.AllBoxTypes() {
 			validTypes = append(validTypes, bt.String())
 		}
@@ -58,17 +58,30 @@ func BorderStyle(style string) error {
 	return nil
 }
 
+// KeyAlign validates a key alignment; empty means the default, left.
+func KeyAlign(align string) error {
+	switch align {
+	case "", box.KeyAlignLeft, box.KeyAlignRight:
+		return nil
+	}
+	return fmt.Errorf("invalid key alignment %q, must be one of: %s, %s", align, box.KeyAlignLeft, box.KeyAlignRight)
+}
+
 // Box performs final validation on a complete box model before rendering.
 // This catches logical errors that pass individual field validation but create
 // invalid combinations, like a box with no displayable content.
 func Box(b *box.Box) error {
 	if !b.HasContent() {
-		return fmt.Errorf("box has no content: provide at least one of --title, --subtitle, --kv, or --footer")
+		return fmt.Errorf("box has no content: provide at least one of --title, --subtitle, --kv, --body, or --footer")
 	}
 
 	if b.Width < 0 {
 		return fmt.Errorf("width must be non-negative, got %d", b.Width)
 	}
 
+	if b.Height < 0 {
+		return fmt.Errorf("height must be non-negative, got %d", b.Height)
+	}
+
 	return nil
 }
@@ -18,6 +18,7 @@ func TestBoxType(t *testing.T) {
 		{"valid error", "error", false},
 		{"valid info", "info", false},
 		{"valid warning", "warning", false},
+		{"pending is internal only", "pending", true},
 		{"invalid type", "invalid", true},
 		{"empty string", "", true},
 		{"wrong case", "Success", true},
@@ -130,6 +131,12 @@ func TestBox(t *testing.T) {
 			wantErr: true,
 			errMsg:  "width must be non-negative",
 		},
+		{
+			name:    "invalid negative height",
+			box:     &box.Box{Type: box.Success, Title: "Test", Height: -1},
+			wantErr: true,
+			errMsg:  "height must be non-negative",
+		},
 		{
 			name:    "valid zero width (auto-size)",
 			box:     &box.Box{Type: box.Success, Title: "Test", Width: 0},
@@ -0,0 +1,159 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// choiceIndent lines the choices up with the box content rather than its border.
+const choiceIndent = 4
+
+var (
+	selectedChoiceStyle = lipgloss.NewStyle().Bold(true).Reverse(true).Padding(0, 1)
+	choiceStyle         = lipgloss.NewStyle().Faint(true).Padding(0, 1)
+	answerYesStyle      = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("42"))
+	answerNoStyle       = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("196"))
+	hintStyle           = lipgloss.NewStyle().Faint(true)
+)
+
+// tickMsg drives the timeout countdown once a second.
+type tickMsg struct{}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+// Confirm is a Bubble Tea model that shows a rendered box with a yes/no choice below
+// it. It runs inline rather than on the alternate screen so the box and the answer
+// stay in the scrollback, the same as a box printed by any other command.
+type Confirm struct {
+	lines      []string
+	labels     [2]string
+	defaultYes bool
+	selected   int
+
+	remaining time.Duration
+
+	done      bool
+	confirmed bool
+	reason    string
+}
+
+// NewConfirm creates a prompt for rendered box lines. The default answer is
+// preselected and is also what a timeout or a missing terminal resolves to; a
+// timeout of zero waits forever.
+func NewConfirm(lines []string, yesLabel, noLabel string, defaultYes bool, timeout time.Duration) Confirm {
+	m := Confirm{
+		lines:      lines,
+		labels:     [2]string{yesLabel, noLabel},
+		defaultYes: defaultYes,
+		remaining:  timeout,
+	}
+	if !defaultYes {
+		m.selected = 1
+	}
+	return m
+}
+
+// Confirmed reports whether the prompt was answered with the affirmative choice.
+func (m Confirm) Confirmed() bool {
+	return m.confirmed
+}
+
+// Default resolves the prompt to its default answer, noting why in the answer line.
+func (m Confirm) Default(reason string) Confirm {
+	return m.answer(m.defaultYes, reason)
+}
+
+func (m Confirm) answer(yes bool, reason string) Confirm {
+	m.done = true
+	m.confirmed = yes
+	m.reason = reason
+	return m
+}
+
+func (m Confirm) Init() tea.Cmd {
+	if m.remaining > 0 {
+		return tick()
+	}
+	return nil
+}
+
+func (m Confirm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.done {
+		return m, tea.Quit
+	}
+
+	switch msg := msg.(type) {
+	case tickMsg:
+		if m.remaining <= 0 {
+			return m, nil
+		}
+		m.remaining -= time.Second
+		if m.remaining <= 0 {
+			return m.Default("timed out"), tea.Quit
+		}
+		return m, tick()
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "right", "h", "l", "tab", "shift+tab":
+			m.selected = 1 - m.selected
+		case "y", "Y":
+			return m.answer(true, ""), tea.Quit
+		case "n", "N":
+			return m.answer(false, ""), tea.Quit
+		case "enter", " ":
+			return m.answer(m.selected == 0, ""), tea.Quit
+		case "esc", "q", "ctrl+c":
+			return m.answer(false, "cancelled"), tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m Confirm) View() string {
+	indent := strings.Repeat(" ", choiceIndent)
+	var out strings.Builder
+	out.WriteString(strings.Join(m.lines, "\n"))
+	out.WriteString("\n")
+
+	if m.done {
+		line := answerNoStyle.Render("✖ " + m.labels[1])
+		if m.confirmed {
+			line = answerYesStyle.Render("✔ " + m.labels[0])
+		}
+		if m.reason != "" {
+			line += " " + hintStyle.Render("("+m.reason+")")
+		}
+		out.WriteString(indent + line + "\n")
+		return out.String()
+	}
+
+	choices := make([]string, len(m.labels))
+	for i, label := range m.labels {
+		if i == m.selected {
+			choices[i] = selectedChoiceStyle.Render(label)
+		} else {
+			choices[i] = choiceStyle.Render(label)
+		}
+	}
+	out.WriteString(indent + strings.Join(choices, "  "))
+
+	hint := "←/→ select • enter confirm • y/n"
+	if m.remaining > 0 {
+		hint += fmt.Sprintf(" • %s in %ds", m.defaultLabel(), int(m.remaining.Round(time.Second).Seconds()))
+	}
+	out.WriteString("  " + hintStyle.Render(hint) + "\n")
+	return out.String()
+}
+
+func (m Confirm) defaultLabel() string {
+	if m.defaultYes {
+		return m.labels[0]
+	}
+	return m.labels[1]
+}
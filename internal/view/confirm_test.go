@@ -0,0 +1,88 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func updateConfirm(m Confirm, msgs ...tea.Msg) (Confirm, tea.Cmd) {
+	var cmd tea.Cmd
+	for _, msg := range msgs {
+		var next tea.Model
+		next, cmd = m.Update(msg)
+		m = next.(Confirm)
+	}
+	return m, cmd
+}
+
+func TestConfirmKeys(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultYes    bool
+		msgs          []tea.Msg
+		wantConfirmed bool
+		wantAnswer    string
+	}{
+		{name: "y answers yes", msgs: keys("y"), wantConfirmed: true, wantAnswer: "✔ Deploy"},
+		{name: "n answers no", defaultYes: true, msgs: keys("n"), wantConfirmed: false, wantAnswer: "✖ Cancel"},
+		{name: "enter takes default no", msgs: []tea.Msg{tea.KeyMsg{Type: tea.KeyEnter}}, wantConfirmed: false, wantAnswer: "✖ Cancel"},
+		{name: "enter takes default yes", defaultYes: true, msgs: []tea.Msg{tea.KeyMsg{Type: tea.KeyEnter}}, wantConfirmed: true, wantAnswer: "✔ Deploy"},
+		{
+			name:          "arrow changes selection",
+			msgs:          []tea.Msg{tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyEnter}},
+			wantConfirmed: true,
+			wantAnswer:    "✔ Deploy",
+		},
+		{
+			name:          "tab twice keeps selection",
+			msgs:          []tea.Msg{tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyTab}, tea.KeyMsg{Type: tea.KeyEnter}},
+			wantConfirmed: false,
+			wantAnswer:    "✖ Cancel",
+		},
+		{name: "ctrl+c cancels", defaultYes: true, msgs: []tea.Msg{tea.KeyMsg{Type: tea.KeyCtrlC}}, wantConfirmed: false, wantAnswer: "✖ Cancel (cancelled)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewConfirm([]string{"BOX"}, "Deploy", "Cancel", tt.defaultYes, 0)
+			m, cmd := updateConfirm(m, tt.msgs...)
+
+			assert.Equal(t, tt.wantConfirmed, m.Confirmed())
+			if assert.NotNil(t, cmd) {
+				assert.Equal(t, tea.Quit(), cmd())
+			}
+			assert.Contains(t, ansi.Strip(m.View()), tt.wantAnswer)
+		})
+	}
+}
+
+func TestConfirmTimeout(t *testing.T) {
+	m := NewConfirm([]string{"BOX"}, "Yes", "No", true, 2*time.Second)
+	assert.NotNil(t, m.Init(), "a timeout starts the countdown")
+	assert.Contains(t, ansi.Strip(m.View()), "Yes in 2s")
+
+	m, cmd := updateConfirm(m, tickMsg{})
+	assert.NotNil(t, cmd)
+	assert.Contains(t, ansi.Strip(m.View()), "Yes in 1s")
+
+	m, cmd = updateConfirm(m, tickMsg{})
+	assert.True(t, m.Confirmed())
+	assert.Equal(t, tea.Quit(), cmd())
+	assert.Contains(t, ansi.Strip(m.View()), "✔ Yes (timed out)")
+}
+
+func TestConfirmWithoutTimeout(t *testing.T) {
+	m := NewConfirm([]string{"BOX"}, "Yes", "No", false, 0)
+	assert.Nil(t, m.Init())
+	assert.NotContains(t, ansi.Strip(m.View()), " in ")
+}
+
+func TestConfirmDefault(t *testing.T) {
+	m := NewConfirm([]string{"BOX"}, "Yes", "No", false, 0).Default("no terminal")
+	assert.False(t, m.Confirmed())
+	assert.Equal(t, "BOX\n    ✖ No (no terminal)\n", ansi.Strip(m.View()))
+}
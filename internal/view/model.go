@@ -0,0 +1,302 @@
+package view
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"boxed/internal/box"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// gutterWidth reserves a column for the cursor marker so selecting a line doesn't
+// shift the box horizontally.
+const gutterWidth = 2
+
+var (
+	cursorMarker   = lipgloss.NewStyle().Bold(true).Render("›") + " "
+	matchStyle     = lipgloss.NewStyle().Reverse(true)
+	statusBarStyle = lipgloss.NewStyle().Faint(true)
+)
+
+// CopyFunc sends a value to the clipboard. It is injected so tests can capture
+// copies and the command can choose how to reach the terminal (OSC 52).
+type CopyFunc func(value string) error
+
+// Model is a Bubble Tea model that shows pre-rendered box lines in a scrollable
+// viewport. It takes lines from the regular renderer rather than re-rendering, so the
+// pager shows exactly the borders and gradients the box would have when printed.
+type Model struct {
+	lines  []string
+	plain  []string
+	values [][]string
+
+	offset int
+	cursor int
+	width  int
+	height int
+
+	searching bool
+	input     string
+	pattern   *regexp.Regexp
+	matches   []int
+
+	status string
+	copy   CopyFunc
+	// copies counts consecutive copies on the cursor line, to step through its values.
+	copies int
+}
+
+// New creates a viewer for rendered box lines. lineKVs holds the KV pairs shown on each
+// line, as returned by the renderer, so copying a wrapped value yields its full text
+// rather than one fragment, without markup unless the box is raw; lines without pairs
+// have nothing to copy.
+func New(lines []string, lineKVs [][]box.KV, copy CopyFunc) Model {
+	m := Model{
+		lines:  lines,
+		plain:  make([]string, len(lines)),
+		values: make([][]string, len(lines)),
+		copy:   copy,
+	}
+	for i, line := range lines {
+		m.plain[i] = ansi.Strip(line)
+		if i < len(lineKVs) {
+			for _, kv := range lineKVs[i] {
+				m.values[i] = append(m.values[i], kv.Value)
+			}
+		}
+	}
+	return m
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scrollToCursor()
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg), nil
+		}
+		return m.updateNormal(msg)
+	}
+	return m, nil
+}
+
+func (m Model) updateNormal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	key := msg.String()
+	if key != "y" && key != "c" {
+		m.copies = 0
+	}
+	switch key {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "down", "j":
+		m.moveCursor(1)
+	case "up", "k":
+		m.moveCursor(-1)
+	case "pgdown", " ", "f":
+		m.moveCursor(m.pageSize())
+	case "pgup", "b":
+		m.moveCursor(-m.pageSize())
+	case "home", "g":
+		m.moveCursor(-len(m.lines))
+	case "end", "G":
+		m.moveCursor(len(m.lines))
+	case "/":
+		m.searching = true
+		m.input = ""
+	case "n":
+		m.jumpToMatch(1)
+	case "N":
+		m.jumpToMatch(-1)
+	case "y", "c":
+		m.copySelected()
+	}
+	return m, nil
+}
+
+func (m Model) updateSearch(msg tea.KeyMsg) Model {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.searching = false
+		m.search(m.input)
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.searching = false
+	case tea.KeyBackspace:
+		if runes := []rune(m.input); len(runes) > 0 {
+			m.input = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.input += string(msg.Runes)
+	}
+	return m
+}
+
+// search finds case-insensitive literal matches. Searching literally (rather than by
+// regular expression) avoids surprising users who search for "1.2.3" or "a+b".
+func (m *Model) search(query string) {
+	m.matches = nil
+	m.pattern = nil
+	if query == "" {
+		return
+	}
+
+	m.pattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	for i, line := range m.plain {
+		if m.pattern.MatchString(line) {
+			m.matches = append(m.matches, i)
+		}
+	}
+
+	if len(m.matches) == 0 {
+		m.status = fmt.Sprintf("no matches for %q", query)
+		return
+	}
+	m.cursor--
+	m.jumpToMatch(1)
+}
+
+// jumpToMatch moves the cursor to the next (dir > 0) or previous match, wrapping around.
+func (m *Model) jumpToMatch(dir int) {
+	if len(m.matches) == 0 {
+		return
+	}
+
+	target := -1
+	if dir > 0 {
+		for _, line := range m.matches {
+			if line > m.cursor {
+				target = line
+				break
+			}
+		}
+		if target < 0 {
+			target = m.matches[0]
+		}
+	} else {
+		for i := len(m.matches) - 1; i >= 0; i-- {
+			if m.matches[i] < m.cursor {
+				target = m.matches[i]
+				break
+			}
+		}
+		if target < 0 {
+			target = m.matches[len(m.matches)-1]
+		}
+	}
+
+	m.cursor = target
+	m.scrollToCursor()
+}
+
+// copySelected copies the value on the cursor line. A line of KV columns holds several
+// values; copying again steps to the next one.
+func (m *Model) copySelected() {
+	if m.copy == nil || m.cursor >= len(m.values) {
+		return
+	}
+	values := m.values[m.cursor]
+	if len(values) == 0 {
+		m.status = "nothing to copy on this line"
+		return
+	}
+	index := m.copies % len(values)
+	m.copies++
+	if err := m.copy(values[index]); err != nil {
+		m.status = "copy failed: " + err.Error()
+		return
+	}
+	m.status = "copied " + ansi.Truncate(values[index], 40, "…")
+	if len(values) > 1 {
+		m.status += fmt.Sprintf(" (%d/%d, y again for the next)", index+1, len(values))
+	}
+}
+
+func (m *Model) moveCursor(delta int) {
+	m.cursor = max(0, min(len(m.lines)-1, m.cursor+delta))
+	m.scrollToCursor()
+}
+
+// scrollToCursor adjusts the offset so the cursor stays inside the viewport.
+func (m *Model) scrollToCursor() {
+	page := m.pageSize()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+page {
+		m.offset = m.cursor - page + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.lines)-page))
+}
+
+// pageSize is the number of box lines visible above the status bar.
+func (m Model) pageSize() int {
+	return max(1, m.height-1)
+}
+
+func (m Model) View() string {
+	var out strings.Builder
+
+	end := min(len(m.lines), m.offset+m.pageSize())
+	for i := m.offset; i < end; i++ {
+		line := m.highlight(i)
+		if m.width > gutterWidth {
+			line = ansi.Truncate(line, m.width-gutterWidth, "…")
+		}
+		if i == m.cursor {
+			out.WriteString(cursorMarker)
+		} else {
+			out.WriteString(strings.Repeat(" ", gutterWidth))
+		}
+		out.WriteString(line)
+		out.WriteString("\n")
+	}
+	for i := end - m.offset; i < m.pageSize(); i++ {
+		out.WriteString("\n")
+	}
+
+	out.WriteString(m.statusBar())
+	return out.String()
+}
+
+// highlight marks search matches on a line using cell ranges over the styled text, so
+// the border and gradient colors around a match are kept.
+func (m Model) highlight(i int) string {
+	if m.pattern == nil {
+		return m.lines[i]
+	}
+
+	plain := m.plain[i]
+	var ranges []lipgloss.Range
+	for _, loc := range m.pattern.FindAllStringIndex(plain, -1) {
+		start := ansi.StringWidth(plain[:loc[0]])
+		end := start + ansi.StringWidth(plain[loc[0]:loc[1]])
+		ranges = append(ranges, lipgloss.NewRange(start, end, matchStyle))
+	}
+	return lipgloss.StyleRanges(m.lines[i], ranges...)
+}
+
+func (m Model) statusBar() string {
+	if m.searching {
+		return "/" + m.input
+	}
+
+	parts := []string{fmt.Sprintf("line %d/%d", m.cursor+1, len(m.lines))}
+	if m.pattern != nil {
+		parts = append(parts, fmt.Sprintf("%d matching lines", len(m.matches)))
+	}
+	if m.status != "" {
+		parts = append(parts, m.status)
+	}
+	parts = append(parts, "/ search • n/N next/prev • y copy • q quit")
+	return statusBarStyle.Render(strings.Join(parts, " • "))
+}
@@ -0,0 +1,209 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"boxed/internal/box"
+	"boxed/internal/render"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestModel(t *testing.T, b *box.Box, copy CopyFunc) Model {
+	t.Helper()
+	lines, lineKVs := render.NewLipGlossRenderer().RenderLinesWithKVs(b)
+	m := New(lines, lineKVs, copy)
+	return update(m, tea.WindowSizeMsg{Width: 120, Height: 6})
+}
+
+func update(m Model, msgs ...tea.Msg) Model {
+	for _, msg := range msgs {
+		next, _ := m.Update(msg)
+		m = next.(Model)
+	}
+	return m
+}
+
+func keys(s string) []tea.Msg {
+	var msgs []tea.Msg
+	for _, r := range s {
+		msgs = append(msgs, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return msgs
+}
+
+func testBox(rows int) *box.Box {
+	b := &box.Box{Type: box.Info, Title: "Inventory"}
+	for i := 0; i < rows; i++ {
+		b.KVPairs = append(b.KVPairs, box.KV{Key: "Host" + string(rune('A'+i)), Value: "node-" + string(rune('a'+i))})
+	}
+	return b
+}
+
+func TestLineValues(t *testing.T) {
+	long := strings.Repeat("word ", 40)
+	b := &box.Box{
+		Type:    box.Info,
+		Title:   "Deploy",
+		KVPairs: []box.KV{{Key: "Notes", Value: long}, {Key: "Env", Value: "prod"}},
+		Layout:  box.KVLayout{Separator: ":"},
+		Footer:  "done",
+	}
+	m := newTestModel(t, b, nil)
+
+	notesLines := 0
+	for i, line := range m.plain {
+		switch {
+		case strings.Contains(line, "Env:"):
+			assert.Equal(t, []string{"prod"}, m.values[i])
+		case strings.Contains(line, "word"):
+			assert.Equal(t, []string{long}, m.values[i], "wrapped line %d should copy the full value", i)
+			notesLines++
+		default:
+			assert.Empty(t, m.values[i], "line %d (%q) has no value", i, line)
+		}
+	}
+	assert.Greater(t, notesLines, 1, "value should wrap over several lines")
+}
+
+func TestNavigation(t *testing.T) {
+	m := newTestModel(t, testBox(10), nil)
+	total := len(m.lines)
+
+	tests := []struct {
+		name       string
+		msgs       []tea.Msg
+		wantCursor int
+		wantOffset int
+	}{
+		{name: "down", msgs: keys("jj"), wantCursor: 2, wantOffset: 0},
+		{name: "up stops at top", msgs: keys("k"), wantCursor: 0, wantOffset: 0},
+		{name: "scrolls with cursor", msgs: keys("jjjjjj"), wantCursor: 6, wantOffset: 2},
+		{name: "page down", msgs: []tea.Msg{tea.KeyMsg{Type: tea.KeyPgDown}}, wantCursor: 5, wantOffset: 1},
+		{name: "bottom", msgs: keys("G"), wantCursor: total - 1, wantOffset: total - 5},
+		{name: "bottom then top", msgs: keys("Gg"), wantCursor: 0, wantOffset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := update(m, tt.msgs...)
+			assert.Equal(t, tt.wantCursor, got.cursor)
+			assert.Equal(t, tt.wantOffset, got.offset)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	m := newTestModel(t, testBox(10), nil)
+
+	m = update(m, keys("/")...)
+	assert.True(t, m.searching)
+	m = update(m, keys("NODE-C")...)
+	assert.Equal(t, "/NODE-C", m.statusBar())
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Len(t, m.matches, 1)
+	assert.False(t, m.searching)
+	assert.Equal(t, m.matches[0], m.cursor)
+	assert.Contains(t, m.plain[m.cursor], "node-c")
+	assert.Contains(t, m.View(), "node-c", "highlighting must keep the matched text")
+	assert.Equal(t, m.plain[m.cursor], ansi.Strip(m.highlight(m.cursor)), "highlighting must not change visible text")
+
+	m = update(m, keys("g/")...)
+	m = update(m, keys("node")...)
+	m = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.Len(t, m.matches, 10)
+	first := m.cursor
+	m = update(m, keys("n")...)
+	assert.Equal(t, m.matches[1], m.cursor)
+	m = update(m, keys("NN")...)
+	assert.Equal(t, m.matches[len(m.matches)-1], m.cursor, "previous match wraps around")
+	assert.Equal(t, m.matches[0], first)
+
+	m = update(m, keys("/")...)
+	m = update(m, keys("missing")...)
+	m = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Empty(t, m.matches)
+	assert.Contains(t, m.statusBar(), `no matches for "missing"`)
+}
+
+func TestSearchTreatsQueryLiterally(t *testing.T) {
+	b := &box.Box{Type: box.Info, KVPairs: []box.KV{{Key: "Version", Value: "1.2.3"}, {Key: "Other", Value: "1x2y3"}}}
+	m := newTestModel(t, b, nil)
+	m.search("1.2.3")
+	assert.Len(t, m.matches, 1)
+}
+
+func TestCopy(t *testing.T) {
+	var copied []string
+	m := newTestModel(t, testBox(3), func(value string) error {
+		copied = append(copied, value)
+		return nil
+	})
+
+	m = update(m, keys("/")...)
+	m = update(m, keys("node-b")...)
+	m = update(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = update(m, keys("y")...)
+
+	assert.Equal(t, []string{"node-b"}, copied)
+	assert.Contains(t, m.statusBar(), "copied node-b")
+
+	m = update(m, keys("g")...)
+	m = update(m, keys("y")...)
+	assert.Len(t, copied, 1, "the border has nothing to copy")
+	assert.Contains(t, m.statusBar(), "nothing to copy")
+}
+
+func TestCopyMarkup(t *testing.T) {
+	var copied []string
+	b := &box.Box{Type: box.Error, Title: "Tests", KVPairs: []box.KV{{Key: "Failed", Value: "{red}3{/} of 40"}}, Markup: true}
+	m := newTestModel(t, b, func(value string) error {
+		copied = append(copied, value)
+		return nil
+	})
+
+	m.search("3 of 40")
+	m = update(m, keys("y")...)
+	assert.Equal(t, []string{"3 of 40"}, copied, "search and copy use the text as shown")
+}
+
+func TestCopyColumns(t *testing.T) {
+	var copied []string
+	b := testBox(4)
+	b.Layout = box.KVLayout{Columns: 2, Compact: true}
+	m := newTestModel(t, b, func(value string) error {
+		copied = append(copied, value)
+		return nil
+	})
+
+	m.search("HostA")
+	m = update(m, keys("yyy")...)
+	assert.Equal(t, []string{"node-a", "node-c", "node-a"}, copied, "copying again steps through the columns")
+	assert.Contains(t, m.statusBar(), "(1/2, y again for the next)")
+
+	m = update(m, keys("jy")...)
+	assert.Equal(t, "node-b", copied[len(copied)-1], "moving starts again at the first column")
+}
+
+func TestQuit(t *testing.T) {
+	m := newTestModel(t, testBox(1), nil)
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyEsc},
+		{Type: tea.KeyCtrlC},
+	} {
+		_, cmd := m.Update(key)
+		require.NotNil(t, cmd, key.String())
+		assert.Equal(t, tea.Quit(), cmd(), key.String())
+	}
+
+	m = update(m, keys("/")...)
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd, "esc cancels the search instead of quitting")
+	assert.False(t, next.(Model).searching)
+}
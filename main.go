@@ -6,10 +6,13 @@ import (
 
 	"boxed/cmd"
 	"boxed/internal/render"
+
+	"github.com/charmbracelet/x/term"
 )
 
 func main() {
 	renderer := render.NewLipGlossRenderer()
+	renderer.Hyperlinks = render.SupportsHyperlinks(os.Getenv, term.IsTerminal(os.Stdout.Fd()))
 	executor := cmd.NewExecutor(renderer, os.Stdout)
 	rootCmd := cmd.NewRootCmd(executor)
 